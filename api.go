@@ -1,13 +1,20 @@
 package tbot
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"mime"
 	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 )
 
 type responseParameters struct {
@@ -24,40 +31,170 @@ type apiResponse struct {
 }
 
 func (c *Client) doRequest(method string, request url.Values, response interface{}) error {
-	endpoint := fmt.Sprintf(c.url, method)
-	var resp *http.Response
-	var err error
-	if request == nil {
-		resp, err = c.httpClient.Post(endpoint, "application/x-www-form-urlencoded", nil)
-	} else {
-		resp, err = c.httpClient.PostForm(endpoint, request)
+	return c.doRequestContext(context.Background(), method, request, response)
+}
+
+func (c *Client) doRequestContext(ctx context.Context, method string, request url.Values, response interface{}) error {
+	result, err := c.doRequestRaw(ctx, method, request)
+	if err != nil {
+		return err
+	}
+	return c.codec.Unmarshal(result, response)
+}
+
+// doRequestRaw applies request timeout and reserved options,
+// then passes the call through client middlewares
+func (c *Client) doRequestRaw(ctx context.Context, method string, request url.Values) (json.RawMessage, error) {
+	if timeout := c.requestTimeout(method, request); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	ctx = stripReserved(ctx, request)
+	return c.handler(ctx, method, request)
+}
+
+// stripReserved removes reserved keys from request, so they are never sent to Telegram.
+// File name set by OptFileName and thumbnail set by OptThumbReader
+// are applied to files of the upload made with ctx.
+func stripReserved(ctx context.Context, request url.Values) context.Context {
+	files := uploadFiles(ctx)
+	filename := request.Get(filenameKey)
+	var thumbnail io.Reader
+	if key := request.Get(thumbnailKey); key != "" {
+		thumbnail = thumbReaders.take(key)
+	}
+	if len(files) > 0 && (filename != "" || thumbnail != nil) {
+		named := make([]inputFile, 0, len(files)+1)
+		for _, file := range files {
+			if file.name == "" {
+				file.name = filename
+			}
+			named = append(named, file)
+		}
+		if thumbnail != nil {
+			named = append(named, namedReaderFile("thumbnail", "thumbnail", thumbnail))
+		}
+		ctx = context.WithValue(ctx, filesKey{}, named)
+	}
+	for k := range request {
+		if strings.HasPrefix(k, "_") {
+			delete(request, k)
+		}
+	}
+	return ctx
+}
+
+// call is the innermost APIHandler performing HTTP request,
+// the request is sent as multipart form if ctx carries files to upload
+func (c *Client) call(ctx context.Context, method string, request url.Values) (json.RawMessage, error) {
+	endpoint, err := c.endpoint(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+	if files := uploadFiles(ctx); len(files) > 0 {
+		return c.upload(ctx, endpoint, request, files)
+	}
+	var body io.Reader
+	if request != nil {
+		body = strings.NewReader(request.Encode())
 	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, body)
 	if err != nil {
-		return fmt.Errorf("unable to send message: %v", err)
+		return nil, err
 	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.send(req.WithContext(ctx))
+}
 
-	apiResp := &apiResponse{}
-	err = json.NewDecoder(resp.Body).Decode(&apiResp)
+// send performs HTTP request to API and returns result of successful response
+func (c *Client) send(req *http.Request) (json.RawMessage, error) {
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("unable to decode sendMessage response: %v", err)
+		return nil, fmt.Errorf("unable to send request: %v", err)
 	}
-	err = resp.Body.Close()
+	defer func() {
+		err := resp.Body.Close()
+		if err != nil {
+			c.logger.Errorf("unable to close response body: %v", err)
+		}
+	}()
+	apiResp := &apiResponse{}
+	err = c.decodeResponse(resp.Body, apiResp)
 	if err != nil {
-		c.logger.Errorf("unable to close response body: %v", err)
+		if resp.StatusCode != http.StatusOK {
+			return nil, &apiError{code: resp.StatusCode, description: fmt.Sprintf("unexpected status code: %s", resp.Status)}
+		}
+		return nil, fmt.Errorf("unable to decode response: %v", err)
 	}
 	if !apiResp.OK {
-		return fmt.Errorf(apiResp.Description)
+		code := apiResp.ErrorCode
+		if code == 0 {
+			code = resp.StatusCode
+		}
+		apiErr := &apiError{code: code, description: apiResp.Description}
+		if apiResp.Parameters != nil {
+			apiErr.retryAfter = time.Duration(apiResp.Parameters.ReplyAfter) * time.Second
+		}
+		return nil, apiErr
 	}
-	return json.Unmarshal(apiResp.Result, response)
+	return apiResp.Result, nil
+}
+
+// apiError is an error reply from Telegram with its error code
+// and the delay requested by Telegram before the call is repeated
+type apiError struct {
+	code        int
+	description string
+	retryAfter  time.Duration
+}
+
+func (e *apiError) Error() string {
+	return e.description
+}
+
+// DoRaw calls Telegram API method with given params and returns raw JSON result.
+// Useful for debugging or accessing fields not mapped to Go types.
+// The call passes through client middlewares like any other API call.
+func (c *Client) DoRaw(ctx context.Context, method string, params url.Values) ([]byte, error) {
+	return c.doRequestRaw(ctx, method, params)
 }
 
 func (c *Client) doRequestWithFiles(method string, request url.Values, response interface{}, files ...inputFile) error {
-	endpoint := fmt.Sprintf(c.url, method)
+	return c.doRequestWithFilesContext(context.Background(), method, request, response, files...)
+}
+
+func (c *Client) doRequestWithFilesContext(ctx context.Context, method string, request url.Values, response interface{}, files ...inputFile) error {
+	return c.doRequestContext(context.WithValue(ctx, filesKey{}, files), method, request, response)
+}
+
+// filesKey is the context key carrying files of the upload through client middlewares to call
+type filesKey struct{}
+
+// uploadFiles returns files of the upload made with ctx
+func uploadFiles(ctx context.Context) []inputFile {
+	files, _ := ctx.Value(filesKey{}).([]inputFile)
+	return files
+}
+
+// hasReaderFiles reports whether the upload made with ctx reads files from io.Reader,
+// such upload can't be sent again as readers are consumed by the first attempt
+func hasReaderFiles(ctx context.Context) bool {
+	for _, file := range uploadFiles(ctx) {
+		if file.reader != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// upload sends request fields and files as multipart form
+func (c *Client) upload(ctx context.Context, endpoint string, request url.Values, files []inputFile) (json.RawMessage, error) {
 	r, w := io.Pipe()
 
 	done := make(chan struct{})
-	var resp *http.Response
-	var err error
+	var result json.RawMessage
+	var sendErr error
 
 	mw := multipart.NewWriter(w)
 
@@ -65,51 +202,101 @@ func (c *Client) doRequestWithFiles(method string, request url.Values, response
 		defer close(done)
 		req, err := http.NewRequest(http.MethodPost, endpoint, r)
 		if err != nil {
-			c.logger.Error(err)
+			sendErr = err
+			r.CloseWithError(err)
 			return
 		}
 		req.Header.Set("Content-Type", mw.FormDataContentType())
-		resp, err = c.httpClient.Do(req)
+		result, sendErr = c.send(req.WithContext(ctx))
 	}()
 
+	err := writeMultipart(mw, request, files)
+	w.CloseWithError(err)
+
+	<-done // post request is done
+	if err != nil && err != io.ErrClosedPipe {
+		return nil, err
+	}
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// writeMultipart writes request fields and files to multipart form.
+// io.ErrClosedPipe means the request has failed before the form was sent.
+func writeMultipart(mw *multipart.Writer, request url.Values, files []inputFile) error {
 	for k := range request {
-		mw.WriteField(k, request.Get(k))
+		err := mw.WriteField(k, request.Get(k))
+		if err != nil {
+			return err
+		}
 	}
 	for _, file := range files {
-		f, err := os.Open(file.name)
+		if file.name == "" {
+			file.name = file.field
+		}
+		f := file.reader
+		if f == nil {
+			osFile, err := os.Open(file.name)
+			if err != nil {
+				return err
+			}
+			defer osFile.Close()
+			f = osFile
+		}
+		fileWriter, err := createFormFile(mw, file.field, file.name)
 		if err != nil {
 			return err
 		}
-		fileWriter, err := mw.CreateFormFile(file.field, file.name)
+		_, err = io.Copy(fileWriter, f)
 		if err != nil {
 			return err
 		}
-
-		io.Copy(fileWriter, f)
-		f.Close()
 	}
+	return mw.Close()
+}
 
-	mw.Close()
-	w.Close()
-
-	<-done // post request is done
+func (c *Client) decodeResponse(body io.Reader, apiResp *apiResponse) error {
+	data, err := ioutil.ReadAll(body)
 	if err != nil {
 		return err
 	}
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected status code: %s", resp.Status)
-	}
-	apiResp := &apiResponse{}
-	err = json.NewDecoder(resp.Body).Decode(&apiResp)
-	if err != nil {
-		return fmt.Errorf("unable to decode sendMessage response: %v", err)
+	return c.codec.Unmarshal(data, apiResp)
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// createFormFile works like multipart.Writer.CreateFormFile,
+// but sets content type detected from file extension instead of application/octet-stream
+func createFormFile(mw *multipart.Writer, field, filename string) (io.Writer, error) {
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	if contentType == "" {
+		contentType = "application/octet-stream"
 	}
-	err = resp.Body.Close()
-	if err != nil {
-		c.logger.Errorf("unable to close response body: %v", err)
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(field), quoteEscaper.Replace(filename)))
+	h.Set("Content-Type", contentType)
+	return mw.CreatePart(h)
+}
+
+// requestTimeout strips OptTimeout value from request and returns timeout for the call.
+// Long polling getUpdates is not limited by default timeout.
+func (c *Client) requestTimeout(method string, request url.Values) time.Duration {
+	value := request.Get(timeoutKey)
+	request.Del(timeoutKey)
+	if value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err == nil {
+			return timeout
+		}
 	}
-	if !apiResp.OK {
-		return fmt.Errorf(apiResp.Description)
+	if method == "getUpdates" {
+		return 0
 	}
-	return json.Unmarshal(apiResp.Result, response)
+	return c.defaultTimeout
 }
@@ -1,49 +1,191 @@
 package tbot
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 // Client is a low-level Telegram client
 type Client struct {
-	token         string
-	url           string
-	httpClient    *http.Client
-	nextOffset    int
-	logger        Logger
-	bufferSize    int
-	timeout       int
-	updatesParams url.Values
-}
-
-// NewClient creates new Telegram API client
-func NewClient(token string, httpClient *http.Client, baseURL string) *Client {
-	return &Client{
+	token          string
+	baseURL        string
+	url            string
+	httpClient     *http.Client
+	nextOffset     int
+	logger         Logger
+	bufferSize     int
+	timeout        int
+	updatesParams  url.Values
+	webhookSecret  string
+	defaultTimeout time.Duration
+	transport      *transportOptions
+	middlewares    []ClientMiddleware
+	handler        APIHandler
+	meMu           sync.Mutex
+	me             *User
+	adminsCache    sync.Map
+	tokenRefresher TokenRefresher
+	tokenMu        sync.RWMutex
+	codec          JSONCodec
+}
+
+// ClientOption type for additional Client options
+type ClientOption func(*Client)
+
+/*
+NewClient creates new Telegram API client. Available options:
+	WithWebhookSecret(secret string)
+	WithDefaultTimeout(d time.Duration)
+	WithPollingTimeout(d time.Duration)
+	WithHTTP2()
+	WithMaxIdleConns(n int)
+	WithMaxConnsPerHost(n int)
+	WithIdleConnTimeout(d time.Duration)
+	WithClientMiddleware(m ...ClientMiddleware)
+	WithTokenRefresher(r TokenRefresher)
+	WithJSONCodec(codec JSONCodec)
+*/
+func NewClient(token string, httpClient *http.Client, baseURL string, options ...ClientOption) *Client {
+	c := &Client{
 		token:      token,
+		baseURL:    baseURL,
 		httpClient: httpClient,
+		logger:     nopLogger{},
+		codec:      stdJSONCodec{},
 		url:        fmt.Sprintf("%s/bot%s/", baseURL, token) + "%s",
 	}
+	for _, opt := range options {
+		opt(c)
+	}
+	if c.transport != nil {
+		c.httpClient = c.transport.apply(httpClient)
+	}
+	c.handler = c.call
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		c.handler = c.middlewares[i](c.handler)
+	}
+	return c
+}
+
+// clone returns a copy of c sharing its HTTP client, options and middlewares,
+// but not caches and locks
+func (c *Client) clone() *Client {
+	c.tokenMu.RLock()
+	token, endpointURL := c.token, c.url
+	c.tokenMu.RUnlock()
+	c.meMu.Lock()
+	me := c.me
+	c.meMu.Unlock()
+	return &Client{
+		token:          token,
+		baseURL:        c.baseURL,
+		url:            endpointURL,
+		httpClient:     c.httpClient,
+		nextOffset:     c.nextOffset,
+		logger:         c.logger,
+		bufferSize:     c.bufferSize,
+		timeout:        c.timeout,
+		updatesParams:  c.updatesParams,
+		webhookSecret:  c.webhookSecret,
+		defaultTimeout: c.defaultTimeout,
+		transport:      c.transport,
+		middlewares:    c.middlewares,
+		handler:        c.handler,
+		me:             me,
+		tokenRefresher: c.tokenRefresher,
+		codec:          c.codec,
+	}
 }
 
+// WithWebhookSecret sets secret token sent by Telegram in every webhook request.
+// It is passed to SetWebhook and checked by webhook handlers.
+func WithWebhookSecret(secret string) ClientOption {
+	return func(c *Client) {
+		c.webhookSecret = secret
+	}
+}
+
+// WithDefaultTimeout sets timeout for all API calls except long polling getUpdates.
+// Use OptTimeout to override it for a single call.
+func WithDefaultTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.defaultTimeout = d
+	}
+}
+
+// WithPollingTimeout sets long polling timeout for getUpdates, 60 seconds by default.
+// The value is rounded down to whole seconds.
+func WithPollingTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.timeout = int(d / time.Second)
+	}
+}
+
+// inputFile is a file to upload, read from reader if set or opened by name otherwise
 type inputFile struct {
-	field string
-	name  string
+	field  string
+	name   string
+	reader io.Reader
+}
+
+// readerFile returns inputFile for data read from r,
+// named by OptFileName or by field if the option is not set
+func readerFile(field string, r io.Reader) inputFile {
+	return namedReaderFile(field, "", r)
+}
+
+// namedReaderFile returns inputFile for data read from r with file name shown in chat
+func namedReaderFile(field, filename string, r io.Reader) inputFile {
+	return inputFile{field: field, name: filename, reader: r}
 }
 
 type sendOption func(url.Values)
 
+// Reserved request keys for options handled by the client, they are never sent to Telegram
+const (
+	timeoutKey   = "_timeout"
+	filenameKey  = "_filename"
+	thumbnailKey = "_thumbnail"
+)
+
+// ParseMode is a text formatting mode
+type ParseMode string
+
+// Parse modes
+const (
+	ParseModeHTML       ParseMode = "HTML"
+	ParseModeMarkdown   ParseMode = "Markdown"
+	ParseModeMarkdownV2 ParseMode = "MarkdownV2"
+)
+
 // Generic message options
 var (
-	OptParseModeHTML = func(r url.Values) {
-		r.Set("parse_mode", "HTML")
+	OptParseMode = func(mode ParseMode) sendOption {
+		return func(r url.Values) {
+			r.Set("parse_mode", string(mode))
+		}
 	}
-	OptParseModeMarkdown = func(r url.Values) {
-		r.Set("parse_mode", "Markdown")
+	OptParseModeHTML       = OptParseMode(ParseModeHTML)
+	OptParseModeMarkdown   = OptParseMode(ParseModeMarkdown)
+	OptParseModeMarkdownV2 = OptParseMode(ParseModeMarkdownV2)
+	OptTimeout             = func(d time.Duration) sendOption {
+		return func(r url.Values) {
+			r.Set(timeoutKey, d.String())
+		}
+	}
+	OptFileName = func(name string) sendOption {
+		return func(r url.Values) {
+			r.Set(filenameKey, name)
+		}
 	}
 	OptDisableNotification = func(r url.Values) {
 		r.Set("disable_notification", "true")
@@ -53,6 +195,26 @@ var (
 			r.Set("reply_to_message_id", strconv.Itoa(id))
 		}
 	}
+	OptEffectID = func(effectID string) sendOption {
+		return func(r url.Values) {
+			r.Set("message_effect_id", effectID)
+		}
+	}
+	OptBusinessConnectionID = func(id string) sendOption {
+		return func(r url.Values) {
+			r.Set("business_connection_id", id)
+		}
+	}
+)
+
+// Message effects for OptEffectID, available in private chats only
+const (
+	EffectFire       = "5104841245755180586"
+	EffectThumbsUp   = "5107584321108051014"
+	EffectThumbsDown = "5104858069142078462"
+	EffectHeart      = "5044134455711629726"
+	EffectParty      = "5046509860389126442"
+	EffectPoop       = "5046589136895476101"
 )
 
 func structString(s interface{}) string {
@@ -67,6 +229,81 @@ func (c *Client) GetMe() (*User, error) {
 	return me, err
 }
 
+// CachedGetMe returns bot User from the first successful GetMe call.
+// Bot info doesn't change for a token, so later calls don't hit the API.
+// Errors are not cached and the next call tries again.
+func (c *Client) CachedGetMe() (*User, error) {
+	c.meMu.Lock()
+	defer c.meMu.Unlock()
+	if c.me != nil {
+		return c.me, nil
+	}
+	me, err := c.GetMe()
+	if err != nil {
+		return nil, err
+	}
+	c.me = me
+	return me, nil
+}
+
+// BotCommand represents a bot command
+type BotCommand struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
+
+// Bot command scope types
+const (
+	BotCommandScopeDefault               = "default"
+	BotCommandScopeAllPrivateChats       = "all_private_chats"
+	BotCommandScopeAllGroupChats         = "all_group_chats"
+	BotCommandScopeAllChatAdministrators = "all_chat_administrators"
+	BotCommandScopeChat                  = "chat"
+	BotCommandScopeChatAdministrators    = "chat_administrators"
+	BotCommandScopeChatMember            = "chat_member"
+)
+
+// BotCommandScope represents the scope to which bot commands are applied.
+// ChatID is required for chat scopes, UserID for chat_member scope.
+type BotCommandScope struct {
+	Type   string `json:"type"`
+	ChatID string `json:"chat_id,omitempty"`
+	UserID int    `json:"user_id,omitempty"`
+}
+
+// SetMyCommands options
+var (
+	OptCommandScope = func(scope BotCommandScope) sendOption {
+		return func(v url.Values) {
+			v.Set("scope", structString(scope))
+		}
+	}
+	OptLanguageCode = func(code string) sendOption {
+		return func(v url.Values) {
+			v.Set("language_code", code)
+		}
+	}
+)
+
+/*
+SetMyCommands changes the list of the bot's commands. Available options:
+	- OptCommandScope(scope BotCommandScope)
+	- OptLanguageCode(code string)
+*/
+func (c *Client) SetMyCommands(commands []BotCommand, opts ...sendOption) error {
+	return c.setMyCommands(context.Background(), commands, opts...)
+}
+
+func (c *Client) setMyCommands(ctx context.Context, commands []BotCommand, opts ...sendOption) error {
+	req := url.Values{}
+	req.Set("commands", c.marshalString(commands))
+	for _, opt := range opts {
+		opt(req)
+	}
+	var set bool
+	return c.doRequestContext(ctx, "setMyCommands", req, &set)
+}
+
 type forceReply struct {
 	ForceReply bool `json:"force_reply"`
 	Selective  bool `json:"selective"`
@@ -84,11 +321,33 @@ type InlineKeyboardMarkup struct {
 
 // InlineKeyboardButton represents one button of an inline keyboard
 type InlineKeyboardButton struct {
-	Text                         string  `json:"text"`
-	URL                          string  `json:"url,omitempty"`
-	CallbackData                 string  `json:"callback_data,omitempty"`
-	SwitchInlineQuery            *string `json:"switch_inline_query,omitempty"`
-	SwitchInlineQueryCurrentChat *string `json:"switch_inline_query_current_chat,omitempty"`
+	Text                         string                       `json:"text"`
+	URL                          string                       `json:"url,omitempty"`
+	CallbackData                 string                       `json:"callback_data,omitempty"`
+	SwitchInlineQuery            *string                      `json:"switch_inline_query,omitempty"`
+	SwitchInlineQueryCurrentChat *string                      `json:"switch_inline_query_current_chat,omitempty"`
+	SwitchInlineQueryChosenChat  *SwitchInlineQueryChosenChat `json:"switch_inline_query_chosen_chat,omitempty"`
+	CopyText                     *CopyTextButton              `json:"copy_text,omitempty"`
+}
+
+// CopyTextButton represents an inline button that copies specified text to the clipboard
+type CopyTextButton struct {
+	Text string `json:"text"`
+}
+
+// NewCopyTextButton returns inline button with label copying textToCopy to the clipboard
+func NewCopyTextButton(label, textToCopy string) InlineKeyboardButton {
+	return InlineKeyboardButton{Text: label, CopyText: &CopyTextButton{Text: textToCopy}}
+}
+
+// SwitchInlineQueryChosenChat represents an inline button that switches the current user to inline mode
+// in a chosen chat, with an optional default inline query
+type SwitchInlineQueryChosenChat struct {
+	Query             string `json:"query,omitempty"`
+	AllowUserChats    bool   `json:"allow_user_chats,omitempty"`
+	AllowBotChats     bool   `json:"allow_bot_chats,omitempty"`
+	AllowGroupChats   bool   `json:"allow_group_chats,omitempty"`
+	AllowChannelChats bool   `json:"allow_channel_chats,omitempty"`
 }
 
 // ReplyKeyboardMarkup represents a custom keyboard with reply options
@@ -106,22 +365,145 @@ type KeyboardButton struct {
 	RequestLocation bool   `json:"request_location"`
 }
 
-func (c *Client) setWebhook(webhookURL string) error {
+// SetWebhook options
+var (
+	OptWebhookSecretToken = func(token string) sendOption {
+		return func(v url.Values) {
+			v.Set("secret_token", token)
+		}
+	}
+	OptWebhookAllowedUpdates = OptAllowedUpdates
+)
+
+/*
+SetWebhook specifies a URL to receive incoming updates via an outgoing webhook.
+Secret token configured with WithWebhookSecret is sent by default. Available options:
+	- OptWebhookSecretToken(token string)
+	- OptWebhookAllowedUpdates(updates ...string), e.g. OptWebhookAllowedUpdates(UpdateTypeMessage, UpdateTypeCallbackQuery)
+*/
+func (c *Client) SetWebhook(webhookURL string, opts ...sendOption) error {
 	req := url.Values{}
 	req.Set("url", webhookURL)
+	if c.webhookSecret != "" {
+		req.Set("secret_token", c.webhookSecret)
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
 	var set bool
 	return c.doRequest("setWebhook", req, &set)
 }
 
+func (c *Client) setWebhook(webhookURL string) error {
+	return c.SetWebhook(webhookURL)
+}
+
 func (c *Client) deleteWebhook() error {
 	var ok bool
 	return c.doRequest("deleteWebhook", url.Values{}, &ok)
 }
 
+const defaultPollTimeout = 60
+
+func (c *Client) getUpdates(ctx context.Context, params url.Values) ([]*Update, error) {
+	var updates []*Update
+	err := c.doRequestContext(ctx, "getUpdates", params, &updates)
+	return updates, err
+}
+
+// startPolling fetches updates until ctx is done, tracking offset
+func (c *Client) startPolling(ctx context.Context, params url.Values, handle func(*Update), onError func(error)) {
+	req := url.Values{}
+	for k, v := range params {
+		req[k] = v
+	}
+	if req.Get("timeout") == "" {
+		timeout := c.timeout
+		if timeout <= 0 {
+			timeout = defaultPollTimeout
+		}
+		req.Set("timeout", fmt.Sprint(timeout))
+	}
+	offset := 0
+	for ctx.Err() == nil {
+		req.Set("offset", fmt.Sprint(offset))
+		updates, err := c.getUpdates(ctx, req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			onError(err)
+			select {
+			case <-time.After(time.Second):
+			case <-ctx.Done():
+			}
+			continue
+		}
+		for _, up := range updates {
+			offset = up.UpdateID + 1
+			handle(up)
+		}
+	}
+}
+
+// GetUpdatesChannel options
+var (
+	OptAllowedUpdates = func(updates ...string) sendOption {
+		return func(v url.Values) {
+			v.Set("allowed_updates", structString(updates))
+		}
+	}
+)
+
+/*
+GetUpdatesChannel starts long polling and streams received updates to the first channel.
+Offset is tracked automatically. Request errors are sent to the second channel
+and polling continues, so both channels should be drained.
+Both channels are closed when ctx is done. Available options:
+	- OptLimit(limit int)
+	- OptAllowedUpdates(updates ...string), e.g. OptAllowedUpdates(UpdateTypeMessage, UpdateTypeCallbackQuery)
+*/
+func (c *Client) GetUpdatesChannel(ctx context.Context, opts ...sendOption) (<-chan *Update, <-chan error) {
+	params := url.Values{}
+	for _, opt := range opts {
+		opt(params)
+	}
+	updates := make(chan *Update, c.bufferSize)
+	errs := make(chan error)
+	go func() {
+		defer close(updates)
+		defer close(errs)
+		c.startPolling(ctx, params, func(up *Update) {
+			select {
+			case updates <- up:
+			case <-ctx.Done():
+			}
+		}, func(err error) {
+			select {
+			case errs <- err:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return updates, errs
+}
+
+// LinkPreviewOptions describes the options used for link preview generation
+type LinkPreviewOptions struct {
+	IsDisabled       bool   `json:"is_disabled,omitempty"`
+	URL              string `json:"url,omitempty"`
+	PreferSmallMedia bool   `json:"prefer_small_media,omitempty"`
+	PreferLargeMedia bool   `json:"prefer_large_media,omitempty"`
+	ShowAboveText    bool   `json:"show_above_text,omitempty"`
+}
+
 // SendMessage options
 var (
-	OptDisableWebPagePreview = func(r url.Values) {
-		r.Set("disable_web_page_preview", "true")
+	OptDisableWebPagePreview = OptLinkPreviewOptions(LinkPreviewOptions{IsDisabled: true})
+	OptLinkPreviewOptions    = func(opts LinkPreviewOptions) sendOption {
+		return func(r url.Values) {
+			r.Set("link_preview_options", structString(opts))
+		}
 	}
 	OptInlineKeyboardMarkup = func(markup *InlineKeyboardMarkup) sendOption {
 		return func(r url.Values) {
@@ -152,7 +534,10 @@ SendMessage sends message to telegram chat. Available options:
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableWebPagePreview
+	- OptLinkPreviewOptions(opts LinkPreviewOptions)
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -162,6 +547,63 @@ SendMessage sends message to telegram chat. Available options:
 	- OptForceReplySelective
 */
 func (c *Client) SendMessage(chatID string, text string, opts ...sendOption) (*Message, error) {
+	return c.sendMessage(context.Background(), chatID, text, opts...)
+}
+
+// SendMessagef formats text with fmt.Sprintf and sends it to telegram chat
+func (c *Client) SendMessagef(ctx context.Context, chatID, format string, args ...interface{}) (*Message, error) {
+	return c.sendMessage(ctx, chatID, fmt.Sprintf(format, args...))
+}
+
+// ReplyToMessage sends text as a reply to received message, see SendMessage for available options
+func (c *Client) ReplyToMessage(msg *Message, text string, opts ...sendOption) (*Message, error) {
+	opts = append([]sendOption{OptReplyToMessageID(msg.MessageID)}, opts...)
+	return c.SendMessage(msg.Chat.ID, text, opts...)
+}
+
+// SendLongMessage splits text with PaginateText and sends every page as a separate message.
+// Already sent messages are returned along with the error.
+func (c *Client) SendLongMessage(ctx context.Context, chatID, text string, opts ...sendOption) ([]*Message, error) {
+	var msgs []*Message
+	for _, page := range PaginateText(text, MaxMessageLength) {
+		msg, err := c.sendMessage(ctx, chatID, page, opts...)
+		if err != nil {
+			return msgs, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// broadcastRate is the global limit of messages per second for a bot
+const broadcastRate = 30
+
+// BroadcastMessage sends text to every chat at most 30 messages per second.
+// Returned errors are in the same order as chatIDs, nil means the message was sent.
+// Chats not reached before ctx is done get ctx error.
+func (c *Client) BroadcastMessage(ctx context.Context, chatIDs []string, text string, opts ...sendOption) []error {
+	errs := make([]error, len(chatIDs))
+	ticker := time.NewTicker(time.Second / broadcastRate)
+	defer ticker.Stop()
+	for i, chatID := range chatIDs {
+		if i > 0 {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+			}
+		}
+		if ctx.Err() != nil {
+			for j := i; j < len(chatIDs); j++ {
+				errs[j] = ctx.Err()
+			}
+			break
+		}
+		_, errs[i] = c.sendMessage(ctx, chatID, text, opts...)
+	}
+	return errs
+}
+
+func (c *Client) sendMessage(ctx context.Context, chatID string, text string, opts ...sendOption) (*Message, error) {
 	req := url.Values{}
 	req.Set("chat_id", chatID)
 	req.Set("text", text)
@@ -169,7 +611,7 @@ func (c *Client) SendMessage(chatID string, text string, opts ...sendOption) (*M
 		opt(req)
 	}
 	msg := &Message{}
-	err := c.doRequest("sendMessage", req, msg)
+	err := c.doRequestContext(ctx, "sendMessage", req, msg)
 	return msg, err
 }
 
@@ -178,6 +620,15 @@ ForwardMessage forwards message from one chat to another. Available options:
 	- OptDisableNotification
 */
 func (c *Client) ForwardMessage(chatID, fromChatID string, messageID int, opts ...sendOption) (*Message, error) {
+	return c.forwardMessage(context.Background(), chatID, fromChatID, messageID, opts...)
+}
+
+// ForwardMessageFrom forwards received message to another chat, see ForwardMessage for available options
+func (c *Client) ForwardMessageFrom(chatID string, msg *Message, opts ...sendOption) (*Message, error) {
+	return c.ForwardMessage(chatID, msg.Chat.ID, msg.MessageID, opts...)
+}
+
+func (c *Client) forwardMessage(ctx context.Context, chatID, fromChatID string, messageID int, opts ...sendOption) (*Message, error) {
 	req := url.Values{}
 	req.Set("chat_id", chatID)
 	req.Set("from_chat_id", fromChatID)
@@ -186,10 +637,120 @@ func (c *Client) ForwardMessage(chatID, fromChatID string, messageID int, opts .
 		opt(req)
 	}
 	msg := &Message{}
-	err := c.doRequest("forwardMessage", req, msg)
+	err := c.doRequestContext(ctx, "forwardMessage", req, msg)
 	return msg, err
 }
 
+// MessageID is a unique message identifier returned by copy methods
+type MessageID struct {
+	MessageID int `json:"message_id"`
+}
+
+// CopyMessage options
+var (
+	// OptCopyReplyMarkup replaces keyboard of the copied message, nil markup removes it.
+	// It is meant for CopyMessage, for other methods use OptInlineKeyboardMarkup.
+	OptCopyReplyMarkup = func(markup *InlineKeyboardMarkup) sendOption {
+		return func(v url.Values) {
+			if markup == nil {
+				markup = &InlineKeyboardMarkup{InlineKeyboard: [][]InlineKeyboardButton{}}
+			}
+			v.Set("reply_markup", structString(markup))
+		}
+	}
+	// OptRemoveCaption copies media without its original caption
+	OptRemoveCaption = func(v url.Values) {
+		v.Set("remove_caption", "true")
+	}
+)
+
+/*
+CopyMessage copies message to another chat without link to the original one.
+Keyboard of the original message is kept. Available options:
+	- OptCaption(caption string)
+	- OptParseModeHTML
+	- OptParseModeMarkdown
+	- OptDisableNotification
+	- OptReplyToMessageID(id int)
+	- OptCopyReplyMarkup(markup *InlineKeyboardMarkup)
+	- OptRemoveCaption
+*/
+func (c *Client) CopyMessage(chatID, fromChatID string, messageID int, opts ...sendOption) (*MessageID, error) {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	req.Set("from_chat_id", fromChatID)
+	req.Set("message_id", strconv.Itoa(messageID))
+	for _, opt := range opts {
+		opt(req)
+	}
+	id := &MessageID{}
+	err := c.doRequest("copyMessage", req, id)
+	return id, err
+}
+
+// CopyMessageFrom copies received message to another chat, see CopyMessage for available options
+func (c *Client) CopyMessageFrom(chatID string, msg *Message, opts ...sendOption) (*MessageID, error) {
+	return c.CopyMessage(chatID, msg.Chat.ID, msg.MessageID, opts...)
+}
+
+/*
+CopyMessages copies messages to another chat without link to the original ones.
+Missing messages are skipped, message ids must be in increasing order. Available options:
+	- OptDisableNotification
+	- OptRemoveCaption
+*/
+func (c *Client) CopyMessages(chatID, fromChatID string, messageIDs []int, opts ...sendOption) ([]*MessageID, error) {
+	return c.copyMessages(context.Background(), chatID, fromChatID, messageIDs, opts...)
+}
+
+func (c *Client) copyMessages(ctx context.Context, chatID, fromChatID string, messageIDs []int, opts ...sendOption) ([]*MessageID, error) {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	req.Set("from_chat_id", fromChatID)
+	ids, _ := c.codec.Marshal(messageIDs)
+	req.Set("message_ids", string(ids))
+	for _, opt := range opts {
+		opt(req)
+	}
+	var result []*MessageID
+	err := c.doRequestContext(ctx, "copyMessages", req, &result)
+	return result, err
+}
+
+// copyMessagesLimit is maximum number of messages copied by a single copyMessages call
+const copyMessagesLimit = 100
+
+// FetchChatHistory returns up to count messages of source chat starting from fromMsgID.
+// Bot API has no method to read chat history, so this is a workaround:
+// messages are copied to privateChatID controlled by the bot, then each copy is
+// forwarded inside that chat to get full Message. Both copies and forwards
+// stay in the private chat. Deleted or inaccessible messages are skipped.
+func (c *Client) FetchChatHistory(ctx context.Context, sourceChatID, privateChatID string, fromMsgID, count int) ([]*Message, error) {
+	var messages []*Message
+	for start := fromMsgID; start < fromMsgID+count; start += copyMessagesLimit {
+		n := fromMsgID + count - start
+		if n > copyMessagesLimit {
+			n = copyMessagesLimit
+		}
+		ids := make([]int, n)
+		for i := range ids {
+			ids[i] = start + i
+		}
+		copies, err := c.copyMessages(ctx, privateChatID, sourceChatID, ids, OptDisableNotification)
+		if err != nil {
+			return messages, err
+		}
+		for _, copied := range copies {
+			msg, err := c.forwardMessage(ctx, privateChatID, privateChatID, copied.MessageID, OptDisableNotification)
+			if err != nil {
+				return messages, err
+			}
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
 // SendAudio options
 var (
 	OptDuration = func(duration int) sendOption {
@@ -218,6 +779,8 @@ SendAudio sends pre-uploaded audio to the chat. Pass fileID of the uploaded file
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -244,9 +807,12 @@ SendAudioFile sends file contents as an audio to the chat. Pass filename to send
 	- OptDuration(duration int)
 	- OptPerformer(performer string)
 	- OptTitle(title string)
+	- OptThumbReader(r io.Reader)
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -266,6 +832,38 @@ func (c *Client) SendAudioFile(chatID string, filename string, opts ...sendOptio
 	return msg, err
 }
 
+/*
+SendAudioReader sends file contents as an audio to the chat. Data is read from r. Available options:
+	- OptFileName(name string)
+	- OptThumbReader(r io.Reader)
+	- OptCaption(caption string)
+	- OptDuration(duration int)
+	- OptPerformer(performer string)
+	- OptTitle(title string)
+	- OptParseModeHTML
+	- OptParseModeMarkdown
+	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
+	- OptReplyToMessageID(id int)
+	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
+	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
+	- OptReplyKeyboardRemove
+	- OptReplyKeyboardRemoveSelective
+	- OptForceReply
+	- OptForceReplySelective
+*/
+func (c *Client) SendAudioReader(chatID string, r io.Reader, opts ...sendOption) (*Message, error) {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	for _, opt := range opts {
+		opt(req)
+	}
+	msg := &Message{}
+	err := c.doRequestWithFiles("sendAudio", req, msg, readerFile("audio", r))
+	return msg, err
+}
+
 // SendPhoto options
 var (
 	OptCaption = func(caption string) sendOption {
@@ -281,6 +879,8 @@ SendPhoto sends pre-uploaded photo to the chat. Pass fileID of the photo. Availa
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -307,6 +907,8 @@ SendPhotoFile sends photo file contents to the chat. Pass filename to send. Avai
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -332,6 +934,8 @@ SendDocument sends document to the chat. Pass fileID of the document. Available
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -358,6 +962,8 @@ SendDocumentFile sends document file contents to the chat. Pass filename to send
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -377,6 +983,62 @@ func (c *Client) SendDocumentFile(chatID string, filename string, opts ...sendOp
 	return msg, err
 }
 
+/*
+SendDocumentReader sends document read from r to the chat. Available options:
+	- OptFileName(name string)
+	- OptCaption(caption string)
+	- OptParseModeHTML
+	- OptParseModeMarkdown
+	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
+	- OptReplyToMessageID(id int)
+	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
+	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
+	- OptReplyKeyboardRemove
+	- OptReplyKeyboardRemoveSelective
+	- OptForceReply
+	- OptForceReplySelective
+*/
+func (c *Client) SendDocumentReader(chatID string, r io.Reader, opts ...sendOption) (*Message, error) {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	for _, opt := range opts {
+		opt(req)
+	}
+	msg := &Message{}
+	err := c.doRequestWithFiles("sendDocument", req, msg, readerFile("document", r))
+	return msg, err
+}
+
+/*
+UploadDocument sends document read from r to the chat.
+displayFilename is the name of the document shown in chat. Available options:
+	- OptCaption(caption string)
+	- OptParseModeHTML
+	- OptParseModeMarkdown
+	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
+	- OptReplyToMessageID(id int)
+	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
+	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
+	- OptReplyKeyboardRemove
+	- OptReplyKeyboardRemoveSelective
+	- OptForceReply
+	- OptForceReplySelective
+*/
+func (c *Client) UploadDocument(ctx context.Context, chatID string, r io.Reader, displayFilename string, opts ...sendOption) (*Message, error) {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	for _, opt := range opts {
+		opt(req)
+	}
+	msg := &Message{}
+	err := c.doRequestWithFilesContext(ctx, "sendDocument", req, msg, namedReaderFile("document", displayFilename, r))
+	return msg, err
+}
+
 // SendVideo options
 var (
 	OptWidth = func(width int) sendOption {
@@ -404,6 +1066,8 @@ SendVideo sends pre-uploaded video to chat. Pass fileID of the uploaded video. A
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -430,10 +1094,13 @@ SendVideoFile sends video file contents to the chat. Pass filename to send. Avai
 	- OptWidth(width int)
 	- OptHeight(height int)
 	- OptSupportsStreaming
+	- OptThumbReader(r io.Reader)
 	- OptCaption(caption string)
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -453,6 +1120,39 @@ func (c *Client) SendVideoFile(chatID string, filename string, opts ...sendOptio
 	return msg, err
 }
 
+/*
+SendVideoReader sends video file contents to the chat. Data is read from r. Available options:
+	- OptFileName(name string)
+	- OptThumbReader(r io.Reader)
+	- OptDuration(duration int)
+	- OptWidth(width int)
+	- OptHeight(height int)
+	- OptSupportsStreaming
+	- OptCaption(caption string)
+	- OptParseModeHTML
+	- OptParseModeMarkdown
+	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
+	- OptReplyToMessageID(id int)
+	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
+	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
+	- OptReplyKeyboardRemove
+	- OptReplyKeyboardRemoveSelective
+	- OptForceReply
+	- OptForceReplySelective
+*/
+func (c *Client) SendVideoReader(chatID string, r io.Reader, opts ...sendOption) (*Message, error) {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	for _, opt := range opts {
+		opt(req)
+	}
+	msg := &Message{}
+	err := c.doRequestWithFiles("sendVideo", req, msg, readerFile("video", r))
+	return msg, err
+}
+
 // SendAnimation options
 var (
 	OptThumb = func(filename string) sendOption {
@@ -460,8 +1160,40 @@ var (
 			v.Set("thumb", filename)
 		}
 	}
+	OptThumbReader = func(r io.Reader) sendOption {
+		return func(v url.Values) {
+			v.Set(thumbnailKey, thumbReaders.put(r))
+		}
+	}
 )
 
+// thumbReaders keeps readers set by OptThumbReader until the request is sent,
+// request carries only the key of the reader, so data is read when the upload is written
+var thumbReaders = &readerRegistry{readers: make(map[string]io.Reader)}
+
+type readerRegistry struct {
+	mu      sync.Mutex
+	next    int
+	readers map[string]io.Reader
+}
+
+func (rr *readerRegistry) put(r io.Reader) string {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	rr.next++
+	key := strconv.Itoa(rr.next)
+	rr.readers[key] = r
+	return key
+}
+
+func (rr *readerRegistry) take(key string) io.Reader {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	r := rr.readers[key]
+	delete(rr.readers, key)
+	return r
+}
+
 /*
 SendAnimation sends animation to chat. Pass fileID to send. Available options:
 	- OptDuration(duration int)
@@ -472,6 +1204,8 @@ SendAnimation sends animation to chat. Pass fileID to send. Available options:
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -505,10 +1239,13 @@ SendAnimationFile sends animation file contents to the chat. Pass filename to se
 	- OptWidth(width int)
 	- OptHeight(height int)
 	- OptThumb(filename string)
+	- OptThumbReader(r io.Reader)
 	- OptCaption(caption string)
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -534,6 +1271,38 @@ func (c *Client) SendAnimationFile(chatID string, filename string, opts ...sendO
 	return msg, err
 }
 
+/*
+SendAnimationReader sends animation file contents to the chat. Data is read from r. Available options:
+	- OptFileName(name string)
+	- OptThumbReader(r io.Reader)
+	- OptDuration(duration int)
+	- OptWidth(width int)
+	- OptHeight(height int)
+	- OptCaption(caption string)
+	- OptParseModeHTML
+	- OptParseModeMarkdown
+	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
+	- OptReplyToMessageID(id int)
+	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
+	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
+	- OptReplyKeyboardRemove
+	- OptReplyKeyboardRemoveSelective
+	- OptForceReply
+	- OptForceReplySelective
+*/
+func (c *Client) SendAnimationReader(chatID string, r io.Reader, opts ...sendOption) (*Message, error) {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	for _, opt := range opts {
+		opt(req)
+	}
+	msg := &Message{}
+	err := c.doRequestWithFiles("sendAnimation", req, msg, readerFile("animation", r))
+	return msg, err
+}
+
 /*
 SendVoice sends audio file as a voice message. Pass file_id of previously uploaded file. Available options:
 	- OptCaption(caption string)
@@ -541,6 +1310,8 @@ SendVoice sends audio file as a voice message. Pass file_id of previously upload
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -568,6 +1339,8 @@ SendVoiceFile sends the audio file as a voice message. Pass filename to send. Av
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -602,6 +1375,8 @@ SendVideoNote sends video note. Pass fileID of previously uploaded video note. A
 	- OptLength(length int)
 	- OptThumb(filename string)
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -635,6 +1410,8 @@ SendVideoNoteFile sends video note to chat. Pass filename to upload. Available o
 	- OptLength(length int)
 	- OptThumb(filename string)
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -699,7 +1476,7 @@ func (InputMediaVideo) inputMedia() {}
 func (c *Client) SendMediaGroup(chatID string, media []InputMedia, opts ...sendOption) ([]*Message, error) {
 	req := url.Values{}
 	req.Set("chat_id", chatID)
-	m, _ := json.Marshal(media)
+	m, _ := c.codec.Marshal(media)
 	req.Set("media", string(m))
 	for _, opt := range opts {
 		opt(req)
@@ -709,6 +1486,65 @@ func (c *Client) SendMediaGroup(chatID string, media []InputMedia, opts ...sendO
 	return msgs, err
 }
 
+// InputPaidMedia describes the paid media to be sent
+type InputPaidMedia interface {
+	inputPaidMedia()
+}
+
+var (
+	_ InputPaidMedia = InputPaidMediaPhoto{}
+	_ InputPaidMedia = InputPaidMediaVideo{}
+)
+
+// InputPaidMediaPhoto is the paid media to send is a photo
+type InputPaidMediaPhoto struct {
+	Type  string `json:"type"`
+	Media string `json:"media"`
+}
+
+func (InputPaidMediaPhoto) inputPaidMedia() {}
+
+// InputPaidMediaVideo is the paid media to send is a video
+type InputPaidMediaVideo struct {
+	Type              string `json:"type"`
+	Media             string `json:"media"`
+	Thumbnail         string `json:"thumbnail,omitempty"`
+	Width             int    `json:"width,omitempty"`
+	Height            int    `json:"height,omitempty"`
+	Duration          int    `json:"duration,omitempty"`
+	SupportsStreaming bool   `json:"supports_streaming,omitempty"`
+}
+
+func (InputPaidMediaVideo) inputPaidMedia() {}
+
+/*
+SendPaidMedia sends paid media to channel chats. Available options:
+	- OptCaption(caption string)
+	- OptParseModeHTML
+	- OptParseModeMarkdown
+	- OptDisableNotification
+	- OptReplyToMessageID(id int)
+	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
+	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
+	- OptReplyKeyboardRemove
+	- OptReplyKeyboardRemoveSelective
+	- OptForceReply
+	- OptForceReplySelective
+*/
+func (c *Client) SendPaidMedia(chatID string, starCount int, media []InputPaidMedia, opts ...sendOption) (*Message, error) {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	req.Set("star_count", fmt.Sprint(starCount))
+	m, _ := c.codec.Marshal(media)
+	req.Set("media", string(m))
+	for _, opt := range opts {
+		opt(req)
+	}
+	msg := &Message{}
+	err := c.doRequest("sendPaidMedia", req, msg)
+	return msg, err
+}
+
 // SendLocation options
 var (
 	OptLivePeriod = func(period int) sendOption {
@@ -716,12 +1552,35 @@ var (
 			v.Set("live_period", fmt.Sprint(period))
 		}
 	}
+	// OptHorizontalAccuracy sets location uncertainty radius in meters, 0-1500
+	OptHorizontalAccuracy = func(meters float64) sendOption {
+		return func(v url.Values) {
+			v.Set("horizontal_accuracy", fmt.Sprint(meters))
+		}
+	}
+	// OptHeading sets direction of movement in degrees, 1-360, for live locations
+	OptHeading = func(degrees int) sendOption {
+		return func(v url.Values) {
+			v.Set("heading", fmt.Sprint(degrees))
+		}
+	}
+	// OptProximityAlertRadius sets distance in meters, 1-100000, for proximity alerts about approaching another chat member, for live locations
+	OptProximityAlertRadius = func(meters int) sendOption {
+		return func(v url.Values) {
+			v.Set("proximity_alert_radius", fmt.Sprint(meters))
+		}
+	}
 )
 
 /*
 SendLocation sends point on the map to chat. Available options:
 	- OptLivePeriod(period int)
+	- OptHorizontalAccuracy(meters float64)
+	- OptHeading(degrees int)
+	- OptProximityAlertRadius(meters int)
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -745,6 +1604,9 @@ func (c *Client) SendLocation(chatID string, latitude, longitude float64, opts .
 
 /*
 EditMessageLiveLocation edits location in message sent by the bot. Available options:
+	- OptHorizontalAccuracy(meters float64)
+	- OptHeading(degrees int)
+	- OptProximityAlertRadius(meters int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
 func (c *Client) EditMessageLiveLocation(chatID string, messageID int, latitude, longitude float64, opts ...sendOption) (*Message, error) {
@@ -763,6 +1625,9 @@ func (c *Client) EditMessageLiveLocation(chatID string, messageID int, latitude,
 
 /*
 EditInlineMessageLiveLocation edits location in message sent via the bot (using inline mode). Available options:
+	- OptHorizontalAccuracy(meters float64)
+	- OptHeading(degrees int)
+	- OptProximityAlertRadius(meters int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
 func (c *Client) EditInlineMessageLiveLocation(inlineMessageID string, latitude, longitude float64, opts ...sendOption) error {
@@ -820,13 +1685,27 @@ var (
 			v.Set("foursquare_type", foursquareType)
 		}
 	}
+	OptGooglePlaceID = func(googlePlaceID string) sendOption {
+		return func(v url.Values) {
+			v.Set("google_place_id", googlePlaceID)
+		}
+	}
+	OptGooglePlaceType = func(googlePlaceType string) sendOption {
+		return func(v url.Values) {
+			v.Set("google_place_type", googlePlaceType)
+		}
+	}
 )
 
 /*
 SendVenue sends information about a venue. Available options:
 	- OptFoursquareID(foursquareID string)
 	- OptFoursquareType(foursquareType string)
+	- OptGooglePlaceID(googlePlaceID string)
+	- OptGooglePlaceType(googlePlaceType string)
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -869,6 +1748,8 @@ SendContact sends phone contact. Available options:
 	- OptLastName(lastName string)
 	- OptVCard(vCard string) TODO: implement vCard support (https://tools.ietf.org/html/rfc6350)
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -970,6 +1851,11 @@ type File struct {
 	FilePath string `json:"file_path"` // use https://api.telegram.org/file/bot<token>/<file_path> to download
 }
 
+// URL returns download link for the file, baseURL is usually https://api.telegram.org
+func (f File) URL(baseURL, token string) string {
+	return fmt.Sprintf("%s/file/bot%s/%s", baseURL, token, f.FilePath)
+}
+
 /*
 GetFile returns File object by fileID.
 */
@@ -981,6 +1867,38 @@ func (c *Client) GetFile(fileID string) (*File, error) {
 	return file, err
 }
 
+// GetFileURL returns download link for the file by fileID
+func (c *Client) GetFileURL(fileID string) (string, error) {
+	file, err := c.GetFile(fileID)
+	if err != nil {
+		return "", err
+	}
+	return file.URL(c.baseURL, c.currentToken()), nil
+}
+
+// DownloadFile downloads file returned by GetFile, caller must close the returned reader
+func (c *Client) DownloadFile(file *File) (io.ReadCloser, error) {
+	resp, err := c.httpClient.Get(file.URL(c.baseURL, c.currentToken()))
+	if err != nil {
+		return nil, fmt.Errorf("unable to download file: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code: %s", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// DownloadProfilePhoto downloads one size of user's profile photo returned by GetUserProfilePhotos.
+// Use FileUniqueID of the size to skip photos that are already downloaded.
+func (c *Client) DownloadProfilePhoto(size PhotoSize) (io.ReadCloser, error) {
+	file, err := c.GetFile(size.FileID)
+	if err != nil {
+		return nil, err
+	}
+	return c.DownloadFile(file)
+}
+
 // KickChatMember options
 var (
 	OptUntilDate = func(date time.Time) sendOption {
@@ -1016,72 +1934,206 @@ func (c *Client) UnbanChatMember(chatID string, userID int) error {
 	return c.doRequest("unbanChatMember", req, &unbanned)
 }
 
-// Restrictions for user in supergroup
-type Restrictions struct {
-	CanSendMessages       bool
-	CanSendMediaMessages  bool
-	CanSendOtherMessages  bool
-	CanAddWebPagePreviews bool
+// ChatPermissions describes actions that a non-administrator user is allowed to take in a chat
+type ChatPermissions struct {
+	CanSendMessages       bool `json:"can_send_messages"`
+	CanSendAudios         bool `json:"can_send_audios"`
+	CanSendDocuments      bool `json:"can_send_documents"`
+	CanSendPhotos         bool `json:"can_send_photos"`
+	CanSendVideos         bool `json:"can_send_videos"`
+	CanSendVideoNotes     bool `json:"can_send_video_notes"`
+	CanSendVoiceNotes     bool `json:"can_send_voice_notes"`
+	CanSendPolls          bool `json:"can_send_polls"`
+	CanSendOtherMessages  bool `json:"can_send_other_messages"`
+	CanAddWebPagePreviews bool `json:"can_add_web_page_previews"`
+	CanChangeInfo         bool `json:"can_change_info"`
+	CanInviteUsers        bool `json:"can_invite_users"`
+	CanPinMessages        bool `json:"can_pin_messages"`
+	CanManageTopics       bool `json:"can_manage_topics"`
 }
 
+// Restrictions for user in supergroup
+//
+// Deprecated: use ChatPermissions
+type Restrictions = ChatPermissions
+
 /*
 RestrictChatMember restrict a user in a supergroup. Available options:
 	- OptUntilDate(date time.Time)
+	- OptUseIndependentChatPermissions
+*/
+func (c *Client) RestrictChatMember(chatID string, userID int, permissions *ChatPermissions, opts ...sendOption) error {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	req.Set("user_id", fmt.Sprint(userID))
+	req.Set("permissions", c.marshalString(permissions))
+	for _, opt := range opts {
+		opt(req)
+	}
+	var restricted bool
+	return c.doRequest("restrictChatMember", req, &restricted)
+}
+
+// SetChatPermissions options
+var (
+	// OptUseIndependentChatPermissions applies each media permission separately.
+	// Without it can_send_other_messages and can_add_web_page_previews imply can_send_polls,
+	// and any media permission implies all of them. Supported since Bot API 6.6,
+	// Telegram apps released before it show only the combined media permission.
+	OptUseIndependentChatPermissions = func(v url.Values) {
+		v.Set("use_independent_chat_permissions", "true")
+	}
+)
+
+/*
+SetChatPermissions set default chat permissions for all members. Available options:
+	- OptUseIndependentChatPermissions
+*/
+func (c *Client) SetChatPermissions(chatID string, permissions *ChatPermissions, opts ...sendOption) error {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	req.Set("permissions", c.marshalString(permissions))
+	for _, opt := range opts {
+		opt(req)
+	}
+	var set bool
+	return c.doRequest("setChatPermissions", req, &set)
+}
+
+// Promotions give user permitions in a supergroup or channel.
+type Promotions struct {
+	IsAnonymous         bool
+	CanManageChat       bool
+	CanManageVideoChats bool
+	CanChangeInfo       bool
+	CanPostMessages     bool
+	CanEditMessages     bool
+	CanDeleteMessages   bool
+	CanInviteUsers      bool
+	CanRestrictMembers  bool
+	CanPinMessages      bool
+	CanPromoteMembers   bool
+}
+
+/*
+PromoteChatMember promote or demote a user in a supergroup or a channel
+*/
+func (c *Client) PromoteChatMember(chatID string, userID int, p *Promotions) error {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	req.Set("user_id", fmt.Sprint(userID))
+	req.Set("is_anonymous", fmt.Sprint(p.IsAnonymous))
+	req.Set("can_manage_chat", fmt.Sprint(p.CanManageChat))
+	req.Set("can_manage_video_chats", fmt.Sprint(p.CanManageVideoChats))
+	req.Set("can_change_info", fmt.Sprint(p.CanChangeInfo))
+	req.Set("can_post_messages", fmt.Sprint(p.CanPostMessages))
+	req.Set("can_edit_messages", fmt.Sprint(p.CanEditMessages))
+	req.Set("can_delete_messages", fmt.Sprint(p.CanDeleteMessages))
+	req.Set("can_invite_users", fmt.Sprint(p.CanInviteUsers))
+	req.Set("can_restrict_members", fmt.Sprint(p.CanRestrictMembers))
+	req.Set("can_pin_messages", fmt.Sprint(p.CanPinMessages))
+	req.Set("can_promote_members", fmt.Sprint(p.CanPromoteMembers))
+	var promoted bool
+	return c.doRequest("promoteChatMember", req, &promoted)
+}
+
+/*
+ExportChatInviteLink generate a new invite link for a chat.
+It is a wrapper around CreateChatInviteLink returning only the link,
+previously generated links are not revoked.
+
+Deprecated: Use CreateChatInviteLink instead.
+*/
+func (c *Client) ExportChatInviteLink(chatID string) (string, error) {
+	link, err := c.CreateChatInviteLink(chatID)
+	if err != nil {
+		return "", err
+	}
+	return link.InviteLink, nil
+}
+
+// ChatInviteLink represents an invite link for a chat
+type ChatInviteLink struct {
+	InviteLink              string `json:"invite_link"`
+	Creator                 User   `json:"creator"`
+	CreatesJoinRequest      bool   `json:"creates_join_request"`
+	IsPrimary               bool   `json:"is_primary"`
+	IsRevoked               bool   `json:"is_revoked"`
+	Name                    string `json:"name"`
+	ExpireDate              int64  `json:"expire_date"`
+	MemberLimit             int    `json:"member_limit"`
+	PendingJoinRequestCount int    `json:"pending_join_request_count"`
+}
+
+// CreateChatInviteLink options
+var (
+	OptInviteLinkName = func(name string) sendOption {
+		return func(v url.Values) {
+			v.Set("name", name)
+		}
+	}
+	OptExpireDate = func(date time.Time) sendOption {
+		return func(v url.Values) {
+			v.Set("expire_date", fmt.Sprint(date.Unix()))
+		}
+	}
+	OptMemberLimit = func(limit int) sendOption {
+		return func(v url.Values) {
+			v.Set("member_limit", fmt.Sprint(limit))
+		}
+	}
+	OptCreatesJoinRequest = func(v url.Values) {
+		v.Set("creates_join_request", "true")
+	}
+)
+
+/*
+CreateChatInviteLink creates an additional invite link for a chat. Available options:
+	- OptInviteLinkName(name string)
+	- OptExpireDate(date time.Time)
+	- OptMemberLimit(limit int)
+	- OptCreatesJoinRequest
 */
-func (c *Client) RestrictChatMember(chatID string, userID int, r *Restrictions, opts ...sendOption) error {
+func (c *Client) CreateChatInviteLink(chatID string, opts ...sendOption) (*ChatInviteLink, error) {
 	req := url.Values{}
 	req.Set("chat_id", chatID)
-	req.Set("user_id", fmt.Sprint(userID))
-	req.Set("can_send_messages", fmt.Sprint(r.CanSendMessages))
-	req.Set("can_send_media_messages", fmt.Sprint(r.CanSendMediaMessages))
-	req.Set("can_send_other_messages", fmt.Sprint(r.CanSendOtherMessages))
-	req.Set("can_add_web_page_previews", fmt.Sprint(r.CanAddWebPagePreviews))
 	for _, opt := range opts {
 		opt(req)
 	}
-	var restricted bool
-	return c.doRequest("restrictChatMember", req, &restricted)
-}
-
-// Promotions give user permitions in a supergroup or channel.
-type Promotions struct {
-	CanChangeInfo      bool
-	CanPostMessages    bool
-	CanEditMessages    bool
-	CanDeleteMessages  bool
-	CanInviteUsers     bool
-	CanRestrictMembers bool
-	CanPinMessages     bool
-	CanPromoteMembers  bool
+	link := &ChatInviteLink{}
+	err := c.doRequest("createChatInviteLink", req, link)
+	return link, err
 }
 
 /*
-PromoteChatMember promote or demote a user in a supergroup or a channel
+EditChatInviteLink edits a non-primary invite link created by the bot. Available options:
+	- OptInviteLinkName(name string)
+	- OptExpireDate(date time.Time)
+	- OptMemberLimit(limit int)
+	- OptCreatesJoinRequest
 */
-func (c *Client) PromoteChatMember(chatID string, userID int, p *Promotions) error {
+func (c *Client) EditChatInviteLink(chatID, inviteLink string, opts ...sendOption) (*ChatInviteLink, error) {
 	req := url.Values{}
 	req.Set("chat_id", chatID)
-	req.Set("user_id", fmt.Sprint(userID))
-	req.Set("can_change_info", fmt.Sprint(p.CanChangeInfo))
-	req.Set("can_post_messages", fmt.Sprint(p.CanPostMessages))
-	req.Set("can_edit_messages", fmt.Sprint(p.CanEditMessages))
-	req.Set("can_delete_messages", fmt.Sprint(p.CanDeleteMessages))
-	req.Set("can_invite_users", fmt.Sprint(p.CanInviteUsers))
-	req.Set("can_restrict_members", fmt.Sprint(p.CanRestrictMembers))
-	req.Set("can_pin_messages", fmt.Sprint(p.CanPinMessages))
-	req.Set("can_promote_member", fmt.Sprint(p.CanPromoteMembers))
-	var promoted bool
-	return c.doRequest("promoteChatMember", req, &promoted)
+	req.Set("invite_link", inviteLink)
+	for _, opt := range opts {
+		opt(req)
+	}
+	link := &ChatInviteLink{}
+	err := c.doRequest("editChatInviteLink", req, link)
+	return link, err
 }
 
 /*
-ExportChatInviteLink generate a new invite link for a chat; any previously generated link is revoked
+RevokeChatInviteLink revokes an invite link created by the bot.
+If the primary link is revoked, a new link is automatically generated.
 */
-func (c *Client) ExportChatInviteLink(chatID string) (string, error) {
+func (c *Client) RevokeChatInviteLink(chatID, inviteLink string) (*ChatInviteLink, error) {
 	req := url.Values{}
 	req.Set("chat_id", chatID)
-	var link string
-	err := c.doRequest("exportChatInviteLink", req, &link)
+	req.Set("invite_link", inviteLink)
+	link := &ChatInviteLink{}
+	err := c.doRequest("revokeChatInviteLink", req, link)
 	return link, err
 }
 
@@ -1095,6 +2147,16 @@ func (c *Client) SetChatPhoto(chatID string, filename string) error {
 	return c.doRequestWithFiles("setChatPhoto", req, &updated, inputFile{field: "photo", name: filename})
 }
 
+/*
+SetChatPhotoReader set a new profile photo for the chat, reading image data from r
+*/
+func (c *Client) SetChatPhotoReader(chatID string, r io.Reader) error {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	var updated bool
+	return c.doRequestWithFiles("setChatPhoto", req, &updated, readerFile("photo", r))
+}
+
 /*
 DeleteChatPhoto deleta a chat photo
 */
@@ -1173,6 +2235,17 @@ func (c *Client) GetChat(chatID string) (*Chat, error) {
 	return chat, err
 }
 
+/*
+GetBusinessConnection get information about the connection of the bot with a business account
+*/
+func (c *Client) GetBusinessConnection(businessConnectionID string) (*BusinessConnection, error) {
+	req := url.Values{}
+	req.Set("business_connection_id", businessConnectionID)
+	conn := &BusinessConnection{}
+	err := c.doRequest("getBusinessConnection", req, conn)
+	return conn, err
+}
+
 // ChatMember contains information about one member of a chat
 type ChatMember struct {
 	User                  User   `json:"user"`
@@ -1194,6 +2267,42 @@ type ChatMember struct {
 	CanAddWebPagePreviews bool   `json:"can_add_web_page_previews"`
 }
 
+// Chat member statuses
+const (
+	StatusCreator       = "creator"
+	StatusAdministrator = "administrator"
+	StatusMember        = "member"
+	StatusRestricted    = "restricted"
+	StatusLeft          = "left"
+	StatusKicked        = "kicked"
+)
+
+// IsAdmin reports whether member is an administrator or creator of the chat
+func (cm ChatMember) IsAdmin() bool {
+	return cm.Status == StatusAdministrator || cm.Status == StatusCreator
+}
+
+// IsChatMember reports whether user is currently in the chat, restricted members included
+func (cm ChatMember) IsChatMember() bool {
+	switch cm.Status {
+	case StatusCreator, StatusAdministrator, StatusMember:
+		return true
+	case StatusRestricted:
+		return cm.IsMember
+	}
+	return false
+}
+
+// IsBanned reports whether user was banned in the chat
+func (cm ChatMember) IsBanned() bool {
+	return cm.Status == StatusKicked
+}
+
+// IsRestricted reports whether user is restricted in the chat
+func (cm ChatMember) IsRestricted() bool {
+	return cm.Status == StatusRestricted
+}
+
 /*
 GetChatAdministrators get a list of administrators in a chat
 */
@@ -1205,6 +2314,30 @@ func (c *Client) GetChatAdministrators(chatID string) ([]*ChatMember, error) {
 	return members, err
 }
 
+type cachedAdministrators struct {
+	members []*ChatMember
+	expires time.Time
+}
+
+/*
+GetChatAdministratorsWithCache returns administrators of a chat, caching result for ttl.
+Cached slice is shared between calls and must not be modified.
+*/
+func (c *Client) GetChatAdministratorsWithCache(chatID string, ttl time.Duration) ([]*ChatMember, error) {
+	if cached, ok := c.adminsCache.Load(chatID); ok {
+		entry := cached.(cachedAdministrators)
+		if time.Now().Before(entry.expires) {
+			return entry.members, nil
+		}
+	}
+	members, err := c.GetChatAdministrators(chatID)
+	if err != nil {
+		return nil, err
+	}
+	c.adminsCache.Store(chatID, cachedAdministrators{members: members, expires: time.Now().Add(ttl)})
+	return members, nil
+}
+
 /*
 GetChatMembersCount returns the number of members in chat
 */
@@ -1228,6 +2361,108 @@ func (c *Client) GetChatMember(chatID string, userID int) (*ChatMember, error) {
 	return member, err
 }
 
+// ChatBoostSource describes the source of a chat boost
+type ChatBoostSource interface {
+	chatBoostSource()
+}
+
+var (
+	_ ChatBoostSource = ChatBoostSourcePremium{}
+	_ ChatBoostSource = ChatBoostSourceGiftCode{}
+	_ ChatBoostSource = ChatBoostSourceGiveaway{}
+)
+
+// ChatBoostSourcePremium is a boost obtained by subscribing to Telegram Premium
+type ChatBoostSourcePremium struct {
+	Source string `json:"source"`
+	User   User   `json:"user"`
+}
+
+func (ChatBoostSourcePremium) chatBoostSource() {}
+
+// ChatBoostSourceGiftCode is a boost obtained by the creation of Telegram Premium gift codes
+type ChatBoostSourceGiftCode struct {
+	Source string `json:"source"`
+	User   User   `json:"user"`
+}
+
+func (ChatBoostSourceGiftCode) chatBoostSource() {}
+
+// ChatBoostSourceGiveaway is a boost obtained by the creation of a giveaway
+type ChatBoostSourceGiveaway struct {
+	Source            string `json:"source"`
+	GiveawayMessageID int    `json:"giveaway_message_id"`
+	User              *User  `json:"user"`
+	IsUnclaimed       bool   `json:"is_unclaimed"`
+}
+
+func (ChatBoostSourceGiveaway) chatBoostSource() {}
+
+func unmarshalChatBoostSource(data json.RawMessage) (ChatBoostSource, error) {
+	s := &struct {
+		Source string `json:"source"`
+	}{}
+	err := json.Unmarshal(data, s)
+	if err != nil {
+		return nil, err
+	}
+	switch s.Source {
+	case "premium":
+		src := ChatBoostSourcePremium{}
+		err = json.Unmarshal(data, &src)
+		return src, err
+	case "gift_code":
+		src := ChatBoostSourceGiftCode{}
+		err = json.Unmarshal(data, &src)
+		return src, err
+	case "giveaway":
+		src := ChatBoostSourceGiveaway{}
+		err = json.Unmarshal(data, &src)
+		return src, err
+	}
+	return nil, nil
+}
+
+// ChatBoost contains information about a chat boost
+type ChatBoost struct {
+	BoostID        string          `json:"boost_id"`
+	AddDate        int             `json:"add_date"`
+	ExpirationDate int             `json:"expiration_date"`
+	Source         ChatBoostSource `json:"source"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cb *ChatBoost) UnmarshalJSON(data []byte) error {
+	type alias ChatBoost
+	s := &struct {
+		*alias
+		Source json.RawMessage `json:"source"`
+	}{alias: (*alias)(cb)}
+	err := json.Unmarshal(data, s)
+	if err != nil {
+		return err
+	}
+	cb.Source, err = unmarshalChatBoostSource(s.Source)
+	return err
+}
+
+// UserChatBoosts represents a list of boosts added to a chat by a user
+type UserChatBoosts struct {
+	Boosts []ChatBoost `json:"boosts"`
+}
+
+/*
+GetUserChatBoosts get the list of boosts added to a chat by a user
+*/
+func (c *Client) GetUserChatBoosts(chatID string, userID int) (*UserChatBoosts, error) {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	req.Set("user_id", fmt.Sprint(userID))
+	boosts := &UserChatBoosts{}
+	err := c.doRequest("getUserChatBoosts", req, boosts)
+	return boosts, err
+}
+
 /*
 SetChatStickerSet set a new group sticker set for a supergroup
 */
@@ -1293,6 +2528,7 @@ EditMessageText edit text and game messages sent by the bot. Available options:
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableWebPagePreview
+	- OptLinkPreviewOptions(opts LinkPreviewOptions)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
 func (c *Client) EditMessageText(chatID string, messageID int, text string, opts ...sendOption) (*Message, error) {
@@ -1313,6 +2549,7 @@ EditInlineMessageText edit text and game messages sent via the bot (for inline b
 	- OptParseModeHTML
 	- OptParseModeMarkdown
 	- OptDisableWebPagePreview
+	- OptLinkPreviewOptions(opts LinkPreviewOptions)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
 func (c *Client) EditInlineMessageText(inlineMessageID, text string, opts ...sendOption) error {
@@ -1403,9 +2640,116 @@ func (c *Client) DeleteMessage(chatID string, messageID int) error {
 	return c.doRequest("deleteMessage", req, &deleted)
 }
 
+/*
+DeleteMessages delete multiple messages at once, up to 100 message ids.
+Messages that can't be found are skipped.
+*/
+func (c *Client) DeleteMessages(chatID string, messageIDs []int) error {
+	return c.deleteMessages(context.Background(), chatID, messageIDs)
+}
+
+func (c *Client) deleteMessages(ctx context.Context, chatID string, messageIDs []int) error {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	ids, _ := c.codec.Marshal(messageIDs)
+	req.Set("message_ids", string(ids))
+	var deleted bool
+	return c.doRequestContext(ctx, "deleteMessages", req, &deleted)
+}
+
+// deleteMessagesLimit is maximum number of messages deleted by a single deleteMessages call
+const deleteMessagesLimit = 100
+
+// BulkDeleteMessages deletes messages with ids from from to to inclusive,
+// in batches of 100. Context is checked before each batch.
+func (c *Client) BulkDeleteMessages(ctx context.Context, chatID string, from, to int) error {
+	for start := from; start <= to; start += deleteMessagesLimit {
+		err := ctx.Err()
+		if err != nil {
+			return err
+		}
+		end := start + deleteMessagesLimit - 1
+		if end > to {
+			end = to
+		}
+		ids := make([]int, 0, end-start+1)
+		for id := start; id <= end; id++ {
+			ids = append(ids, id)
+		}
+		err = c.deleteMessages(ctx, chatID, ids)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReactionType describes the type of a reaction
+type ReactionType interface {
+	reactionType()
+}
+
+var (
+	_ ReactionType = ReactionTypeEmoji{}
+	_ ReactionType = ReactionTypeCustomEmoji{}
+)
+
+// ReactionTypeEmoji is a reaction based on an emoji
+type ReactionTypeEmoji struct {
+	Type  string `json:"type"`
+	Emoji string `json:"emoji"`
+}
+
+func (ReactionTypeEmoji) reactionType() {}
+
+// ReactionTypeCustomEmoji is a reaction based on a custom emoji
+type ReactionTypeCustomEmoji struct {
+	Type          string `json:"type"`
+	CustomEmojiID string `json:"custom_emoji_id"`
+}
+
+func (ReactionTypeCustomEmoji) reactionType() {}
+
+// SetMessageReaction options
+var (
+	OptIsBig = func(v url.Values) {
+		v.Set("is_big", "true")
+	}
+)
+
+/*
+SetMessageReaction change the chosen reactions on a message. Available options:
+	- OptIsBig
+*/
+func (c *Client) SetMessageReaction(chatID string, messageID int, reaction []ReactionType, opts ...sendOption) error {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	req.Set("message_id", fmt.Sprint(messageID))
+	r, _ := c.codec.Marshal(reaction)
+	req.Set("reaction", string(r))
+	for _, opt := range opts {
+		opt(req)
+	}
+	var set bool
+	return c.doRequest("setMessageReaction", req, &set)
+}
+
+// SendSticker options
+var (
+	// OptStickerEmoji sets emoji shown for the sticker, used for just uploaded stickers
+	OptStickerEmoji = func(emoji string) sendOption {
+		return func(v url.Values) {
+			v.Set("emoji", emoji)
+		}
+	}
+)
+
 /*
 SendStickerFile send .webp file sticker. Available options:
+	- OptStickerEmoji(emoji string)
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -1425,9 +2769,38 @@ func (c *Client) SendStickerFile(chatID string, filename string, opts ...sendOpt
 	return msg, err
 }
 
+/*
+SendStickerReader send sticker read from r. Available options:
+	- OptFileName(name string)
+	- OptStickerEmoji(emoji string)
+	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
+	- OptReplyToMessageID(id int)
+	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
+	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
+	- OptReplyKeyboardRemove
+	- OptReplyKeyboardRemoveSelective
+	- OptForceReply
+	- OptForceReplySelective
+*/
+func (c *Client) SendStickerReader(chatID string, r io.Reader, opts ...sendOption) (*Message, error) {
+	req := url.Values{}
+	req.Set("chat_id", chatID)
+	for _, opt := range opts {
+		opt(req)
+	}
+	msg := &Message{}
+	err := c.doRequestWithFiles("sendSticker", req, msg, readerFile("sticker", r))
+	return msg, err
+}
+
 /*
 SendSticker send previously uploaded sticker. Available options:
+	- OptStickerEmoji(emoji string)
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -1450,14 +2823,31 @@ func (c *Client) SendSticker(chatID, fileID string, opts ...sendOption) (*Messag
 
 // StickerSet represents sticker set
 type StickerSet struct {
-	Name          string    `json:"name"`
-	Title         string    `json:"title"`
-	ContainsMasks bool      `json:"contains_masks"`
-	Stickers      []Sticker `json:"stickers"`
+	Name          string     `json:"name"`
+	Title         string     `json:"title"`
+	StickerType   string     `json:"sticker_type"`
+	ContainsMasks bool       `json:"contains_masks"` // Deprecated: use StickerType
+	Stickers      []Sticker  `json:"stickers"`
+	Thumbnail     *PhotoSize `json:"thumbnail"` // set by SetStickerSetThumbnail
+}
+
+// IsRegular reports whether the set contains regular stickers
+func (ss *StickerSet) IsRegular() bool {
+	return ss.StickerType == StickerTypeRegular
+}
+
+// IsMask reports whether the set contains masks
+func (ss *StickerSet) IsMask() bool {
+	return ss.StickerType == StickerTypeMask
+}
+
+// IsCustomEmoji reports whether the set contains custom emoji
+func (ss *StickerSet) IsCustomEmoji() bool {
+	return ss.StickerType == StickerTypeCustomEmoji
 }
 
 /*
-GetStickerSet get a sticker set
+GetStickerSet get a sticker set with its stickers and thumbnail
 */
 func (c *Client) GetStickerSet(name string) (*StickerSet, error) {
 	req := url.Values{}
@@ -1467,6 +2857,19 @@ func (c *Client) GetStickerSet(name string) (*StickerSet, error) {
 	return set, err
 }
 
+/*
+GetCustomEmojiStickers get information about custom emoji stickers by their identifiers.
+At most 200 custom emoji identifiers can be specified.
+*/
+func (c *Client) GetCustomEmojiStickers(customEmojiIDs []string) ([]*Sticker, error) {
+	req := url.Values{}
+	ids, _ := c.codec.Marshal(customEmojiIDs)
+	req.Set("custom_emoji_ids", string(ids))
+	var stickers []*Sticker
+	err := c.doRequest("getCustomEmojiStickers", req, &stickers)
+	return stickers, err
+}
+
 /*
 UploadStickerFile upload a .png file with a sticker for later use in CreateNewStickerSet and AddStickerToSet
 */
@@ -1491,6 +2894,29 @@ var (
 	}
 )
 
+// validateMaskPosition checks mask position set by OptMaskPosition,
+// since Telegram replies with bare Bad Request on invalid one
+func validateMaskPosition(req url.Values) error {
+	value := req.Get("mask_position")
+	if value == "" || value == "null" {
+		return nil
+	}
+	pos := &MaskPosition{}
+	err := json.Unmarshal([]byte(value), pos)
+	if err != nil {
+		return fmt.Errorf("invalid mask position: %v", err)
+	}
+	switch pos.Point {
+	case MaskPointForehead, MaskPointEyes, MaskPointMouth, MaskPointChin:
+	default:
+		return fmt.Errorf("invalid mask position point %q: must be one of forehead, eyes, mouth, chin", pos.Point)
+	}
+	if pos.Scale < 0.01 || pos.Scale > 10 {
+		return fmt.Errorf("invalid mask position scale %v: must be between 0.01 and 10", pos.Scale)
+	}
+	return nil
+}
+
 /*
 CreateNewStickerSetFile creates new sticker set with sticker file. Available options:
 	- OptContainsMasks
@@ -1505,6 +2931,9 @@ func (c *Client) CreateNewStickerSetFile(userID int, name, title, stickerFilenam
 	for _, opt := range opts {
 		opt(req)
 	}
+	if err := validateMaskPosition(req); err != nil {
+		return err
+	}
 	var created bool
 	return c.doRequestWithFiles("createNewStickerSet", req, &created, inputFile{field: "png_sticker", name: stickerFilename})
 }
@@ -1524,13 +2953,41 @@ func (c *Client) CreateNewStickerSet(userID int, name, title, fileID, emojis str
 	for _, opt := range opts {
 		opt(req)
 	}
+	if err := validateMaskPosition(req); err != nil {
+		return err
+	}
 	var created bool
 	return c.doRequest("createNewStickerSet", req, &created)
 }
 
+// AddStickerToSet options
+var (
+	// OptStickerName overrides sticker set name passed to AddStickerToSet
+	OptStickerName = func(name string) sendOption {
+		return func(v url.Values) {
+			v.Set("name", name)
+		}
+	}
+)
+
+// validateStickerSetName checks that set name ends with _by_<bot username> as Telegram requires
+func (c *Client) validateStickerSetName(req url.Values) error {
+	me, err := c.CachedGetMe()
+	if err != nil {
+		return err
+	}
+	name := req.Get("name")
+	suffix := "_by_" + me.Username
+	if !strings.HasSuffix(strings.ToLower(name), strings.ToLower(suffix)) {
+		return fmt.Errorf("invalid sticker set name %q: must end with %q", name, suffix)
+	}
+	return nil
+}
+
 /*
 AddStickerToSetFile add a new sticker file to a set created by the bot. Available options:
 	- OptMaskPosition(pos *MaskPosition)
+	- OptStickerName(name string)
 */
 func (c *Client) AddStickerToSetFile(userID int, name, filename, emojis string, opts ...sendOption) error {
 	req := url.Values{}
@@ -1540,6 +2997,12 @@ func (c *Client) AddStickerToSetFile(userID int, name, filename, emojis string,
 	for _, opt := range opts {
 		opt(req)
 	}
+	if err := validateMaskPosition(req); err != nil {
+		return err
+	}
+	if err := c.validateStickerSetName(req); err != nil {
+		return err
+	}
 	var added bool
 	return c.doRequestWithFiles("addStickerToSet", req, &added, inputFile{field: "png_sticker", name: filename})
 }
@@ -1547,6 +3010,7 @@ func (c *Client) AddStickerToSetFile(userID int, name, filename, emojis string,
 /*
 AddStickerToSet add a new sticker to a set created by the bot. Available options:
 	- OptMaskPosition(pos *MaskPosition)
+	- OptStickerName(name string)
 */
 func (c *Client) AddStickerToSet(userID int, name, fileID, emojis string, opts ...sendOption) error {
 	req := url.Values{}
@@ -1557,14 +3021,23 @@ func (c *Client) AddStickerToSet(userID int, name, fileID, emojis string, opts .
 	for _, opt := range opts {
 		opt(req)
 	}
+	if err := validateMaskPosition(req); err != nil {
+		return err
+	}
+	if err := c.validateStickerSetName(req); err != nil {
+		return err
+	}
 	var added bool
 	return c.doRequestWithFiles("addStickerToSet", req, &added)
 }
 
 /*
-SetStickerPositionInSet move a sticker in a set created by the bot to a specific position
+SetStickerPositionInSet move a sticker in a set created by the bot to a specific zero-based position
 */
 func (c *Client) SetStickerPositionInSet(fileID string, pos int) error {
+	if pos < 0 {
+		return fmt.Errorf("position must be ≥ 0, got %d", pos)
+	}
 	req := url.Values{}
 	req.Set("sticker", fileID)
 	req.Set("position", fmt.Sprint(pos))
@@ -1572,6 +3045,11 @@ func (c *Client) SetStickerPositionInSet(fileID string, pos int) error {
 	return c.doRequest("setStickerPositionInSet", req, &set)
 }
 
+// MoveStickerToFront move a sticker to the first position in a set created by the bot
+func (c *Client) MoveStickerToFront(fileID string) error {
+	return c.SetStickerPositionInSet(fileID, 0)
+}
+
 /*
 DeleteStickerFromSet delete a sticker from a set created by the bot
 */
@@ -1582,6 +3060,39 @@ func (c *Client) DeleteStickerFromSet(fileID string) error {
 	return c.doRequest("deleteStickerFromSet", req, &deleted)
 }
 
+// InputSticker describes a sticker to be added to a sticker set
+type InputSticker struct {
+	// Sticker is file id, HTTP URL or attach://<path> to upload local file
+	Sticker string `json:"sticker"`
+	// Format is one of static, animated or video
+	Format       string        `json:"format"`
+	EmojiList    []string      `json:"emoji_list"`
+	MaskPosition *MaskPosition `json:"mask_position,omitempty"`
+	Keywords     []string      `json:"keywords,omitempty"`
+}
+
+const inputStickerAttachPrefix = "attach://"
+
+/*
+ReplaceStickerInSet replace an existing sticker in a sticker set with a new one.
+Sticker with attach://<path> is uploaded from local file.
+*/
+func (c *Client) ReplaceStickerInSet(userID int, name, oldSticker string, sticker InputSticker) error {
+	req := url.Values{}
+	req.Set("user_id", fmt.Sprint(userID))
+	req.Set("name", name)
+	req.Set("old_sticker", oldSticker)
+	var replaced bool
+	if !strings.HasPrefix(sticker.Sticker, inputStickerAttachPrefix) {
+		req.Set("sticker", c.marshalString(sticker))
+		return c.doRequest("replaceStickerInSet", req, &replaced)
+	}
+	filename := strings.TrimPrefix(sticker.Sticker, inputStickerAttachPrefix)
+	sticker.Sticker = inputStickerAttachPrefix + "sticker_file"
+	req.Set("sticker", c.marshalString(sticker))
+	return c.doRequestWithFiles("replaceStickerInSet", req, &replaced, inputFile{field: "sticker_file", name: filename})
+}
+
 // InputMessageContent content of a message to be sent as a result of an inline query
 type InputMessageContent interface {
 	inputMessageContent()
@@ -1614,12 +3125,14 @@ func (InputLocationMessageContent) inputMessageContent() {}
 
 // InputVenueMessageContent represents the content of a venue message to be sent as the result of an inline query
 type InputVenueMessageContent struct {
-	Latitude       float64 `json:"latitude"`
-	Longitude      float64 `json:"longitude"`
-	Title          string  `json:"title"`
-	Address        string  `json:"address"`
-	FoursquareID   string  `json:"foursquare_id"`
-	FoursquareType string  `json:"foursquare_type"`
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+	Title           string  `json:"title"`
+	Address         string  `json:"address"`
+	FoursquareID    string  `json:"foursquare_id"`
+	FoursquareType  string  `json:"foursquare_type"`
+	GooglePlaceID   string  `json:"google_place_id"`
+	GooglePlaceType string  `json:"google_place_type"`
 }
 
 func (InputVenueMessageContent) inputMessageContent() {}
@@ -1679,6 +3192,40 @@ type InlineQueryResultArticle struct {
 
 func (InlineQueryResultArticle) inlineQueryResult() {}
 
+// NewArticleResult returns article result with required fields set, sending messageText when chosen
+func NewArticleResult(id, title, messageText string) *InlineQueryResultArticle {
+	return &InlineQueryResultArticle{
+		Type:                "article",
+		ID:                  id,
+		Title:               title,
+		InputMessageContent: InputTextMessageContent{MessageText: messageText},
+	}
+}
+
+// WithDescription sets short description of the result
+func (a *InlineQueryResultArticle) WithDescription(description string) *InlineQueryResultArticle {
+	a.Description = description
+	return a
+}
+
+// WithURL sets URL of the result
+func (a *InlineQueryResultArticle) WithURL(url string) *InlineQueryResultArticle {
+	a.URL = url
+	return a
+}
+
+// WithThumbURL sets URL of the thumbnail for the result
+func (a *InlineQueryResultArticle) WithThumbURL(url string) *InlineQueryResultArticle {
+	a.ThumbURL = url
+	return a
+}
+
+// WithReplyMarkup sets inline keyboard attached to the message
+func (a *InlineQueryResultArticle) WithReplyMarkup(markup *InlineKeyboardMarkup) *InlineQueryResultArticle {
+	a.ReplyMarkup = markup
+	return a
+}
+
 // InlineQueryResultPhoto represents a link to a photo
 type InlineQueryResultPhoto struct {
 	Type                string                `json:"type"`
@@ -1831,6 +3378,8 @@ type InlineQueryResultVenue struct {
 	Address             string                `json:"address"`
 	FoursquareID        string                `json:"foursquare_id,omitempty"`
 	FoursquareType      string                `json:"foursquare_type,omitempty"`
+	GooglePlaceID       string                `json:"google_place_id,omitempty"`
+	GooglePlaceType     string                `json:"google_place_type,omitempty"`
 	ReplyMarkup         *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 	InputMessageContent *InputMessageContent  `json:"input_message_content,omitempty"`
 	ThumbURL            string                `json:"thumb_url,omitempty"`
@@ -2012,7 +3561,7 @@ AnswerInlineQuery send answer to an inline query. No more than 50 results per qu
 func (c *Client) AnswerInlineQuery(inlineQueryID string, results []InlineQueryResult, opts ...sendOption) error {
 	req := url.Values{}
 	req.Set("inline_query_id", inlineQueryID)
-	res, _ := json.Marshal(results)
+	res, _ := c.codec.Marshal(results)
 	req.Set("results", string(res))
 	for _, opt := range opts {
 		opt(req)
@@ -2090,7 +3639,7 @@ func (c *Client) SendInvoice(chatID, payload, providerToken string, invoice *Inv
 	req.Set("provider_token", providerToken)
 	req.Set("start_parameter", invoice.StartParameter)
 	req.Set("currency", invoice.Currency)
-	pr, _ := json.Marshal(prices)
+	pr, _ := c.codec.Marshal(prices)
 	req.Set("prices", string(pr))
 	for _, opt := range opts {
 		opt(req)
@@ -2123,7 +3672,8 @@ var (
 )
 
 /*
-AnswerShippingQuery reply to shipping queries. Available options:
+AnswerShippingQuery reply to shipping queries.
+OptShippingOptions is required when ok is true, OptErrorMessage is required otherwise. Available options:
 	- OptShippingOptions(options []ShippingOption)
 	- OptErrorMessage(msg string)
 */
@@ -2134,6 +3684,12 @@ func (c *Client) AnswerShippingQuery(shippingQueryID string, ok bool, opts ...se
 	for _, opt := range opts {
 		opt(req)
 	}
+	switch options := req.Get("shipping_options"); {
+	case ok && (options == "" || options == "null" || options == "[]"):
+		return fmt.Errorf("ok=true requires at least one shipping option")
+	case !ok && req.Get("error_message") == "":
+		return fmt.Errorf("ok=false requires an error message")
+	}
 	var answered bool
 	return c.doRequest("answerShippingQuery", req, &answered)
 }
@@ -2178,6 +3734,11 @@ type PassportElementErrorDataField struct {
 
 func (PassportElementErrorDataField) passportElementError() {}
 
+// NewDataFieldError returns error in the data field with source set to data
+func NewDataFieldError(elementType, fieldName, dataHash, msg string) PassportElementErrorDataField {
+	return PassportElementErrorDataField{Source: "data", Type: elementType, FieldName: fieldName, DataHash: dataHash, Message: msg}
+}
+
 // PassportElementErrorFrontSide represents an issue with the front side of a document
 type PassportElementErrorFrontSide struct {
 	Source   string `json:"source"`
@@ -2188,6 +3749,11 @@ type PassportElementErrorFrontSide struct {
 
 func (PassportElementErrorFrontSide) passportElementError() {}
 
+// NewFrontSideError returns error in the document front side with source set to front_side
+func NewFrontSideError(elementType, fileHash, msg string) PassportElementErrorFrontSide {
+	return PassportElementErrorFrontSide{Source: "front_side", Type: elementType, FileHash: fileHash, Message: msg}
+}
+
 // PassportElementErrorReverseSide represents an issue with the reverse side of a document
 type PassportElementErrorReverseSide struct {
 	Source   string `json:"source"`
@@ -2198,6 +3764,11 @@ type PassportElementErrorReverseSide struct {
 
 func (PassportElementErrorReverseSide) passportElementError() {}
 
+// NewReverseSideError returns error in the document reverse side with source set to reverse_side
+func NewReverseSideError(elementType, fileHash, msg string) PassportElementErrorReverseSide {
+	return PassportElementErrorReverseSide{Source: "reverse_side", Type: elementType, FileHash: fileHash, Message: msg}
+}
+
 // PassportElementErrorSelfie represents an issue with the selfie with a document
 type PassportElementErrorSelfie struct {
 	Source   string `json:"source"`
@@ -2208,6 +3779,11 @@ type PassportElementErrorSelfie struct {
 
 func (PassportElementErrorSelfie) passportElementError() {}
 
+// NewSelfieError returns error in the document selfie with source set to selfie
+func NewSelfieError(elementType, fileHash, msg string) PassportElementErrorSelfie {
+	return PassportElementErrorSelfie{Source: "selfie", Type: elementType, FileHash: fileHash, Message: msg}
+}
+
 // PassportElementErrorFile represents an issue with a document scan
 type PassportElementErrorFile struct {
 	Source   string `json:"source"`
@@ -2218,6 +3794,11 @@ type PassportElementErrorFile struct {
 
 func (PassportElementErrorFile) passportElementError() {}
 
+// NewFileError returns error in the document scan with source set to file
+func NewFileError(elementType, fileHash, msg string) PassportElementErrorFile {
+	return PassportElementErrorFile{Source: "file", Type: elementType, FileHash: fileHash, Message: msg}
+}
+
 // PassportElementErrorFiles represents an issue with a list of scans
 type PassportElementErrorFiles struct {
 	Source     string   `json:"source"`
@@ -2228,21 +3809,97 @@ type PassportElementErrorFiles struct {
 
 func (PassportElementErrorFiles) passportElementError() {}
 
+// NewFilesError returns error in the document scans with source set to files
+func NewFilesError(elementType string, fileHashes []string, msg string) PassportElementErrorFiles {
+	return PassportElementErrorFiles{Source: "files", Type: elementType, FileHashes: fileHashes, Message: msg}
+}
+
 /*
 SetPassportDataErrors informs a user that some of the Telegram Passport elements they provided contains errors
 */
 func (c *Client) SetPassportDataErrors(userID int, errors []PassportElementError) error {
 	req := url.Values{}
 	req.Set("user_id", fmt.Sprint(userID))
-	errs, _ := json.Marshal(errors)
+	errs, _ := c.codec.Marshal(errors)
 	req.Set("errors", string(errs))
 	var set bool
 	return c.doRequest("setPassportDataErrors", req, &set)
 }
 
+// Gift represents a gift that can be sent by the bot
+type Gift struct {
+	ID             string  `json:"id"`
+	Sticker        Sticker `json:"sticker"`
+	StarCount      int     `json:"star_count"`
+	TotalCount     int     `json:"total_count"`
+	RemainingCount int     `json:"remaining_count"`
+}
+
+// Gifts represent a list of gifts
+type Gifts struct {
+	Gifts []Gift `json:"gifts"`
+}
+
+/*
+GetAvailableGifts returns the list of gifts that can be sent by the bot to users
+*/
+func (c *Client) GetAvailableGifts() (*Gifts, error) {
+	gifts := &Gifts{}
+	err := c.doRequest("getAvailableGifts", nil, gifts)
+	return gifts, err
+}
+
+// SendGift options
+var (
+	OptTextParseMode = func(mode ParseMode) sendOption {
+		return func(v url.Values) {
+			v.Set("text_parse_mode", string(mode))
+		}
+	}
+	OptTextEntities = func(entities []*MessageEntity) sendOption {
+		return func(v url.Values) {
+			v.Set("text_entities", structString(entities))
+		}
+	}
+)
+
+/*
+SendGift sends a gift to the given user. The gift can't be converted to Telegram Stars by the user. Available options:
+	- OptText(text string)
+	- OptTextParseMode(mode ParseMode)
+	- OptTextEntities(entities []*MessageEntity)
+*/
+func (c *Client) SendGift(userID int, giftID string, opts ...sendOption) error {
+	req := url.Values{}
+	req.Set("user_id", fmt.Sprint(userID))
+	req.Set("gift_id", giftID)
+	for _, opt := range opts {
+		opt(req)
+	}
+	var sent bool
+	return c.doRequest("sendGift", req, &sent)
+}
+
+// StarAmount describes an amount of Telegram Stars
+type StarAmount struct {
+	Amount         int `json:"amount"`
+	NanostarAmount int `json:"nanostar_amount"`
+}
+
+/*
+GetMyStarBalance returns the current Telegram Stars balance of the bot.
+Fractional part (StarAmount.NanostarAmount) is dropped.
+*/
+func (c *Client) GetMyStarBalance() (int, error) {
+	balance := &StarAmount{}
+	err := c.doRequest("getMyStarBalance", nil, balance)
+	return balance.Amount, err
+}
+
 /*
 SendGame send a game. Available options:
 	- OptDisableNotification
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 */
@@ -2269,7 +3926,8 @@ var (
 )
 
 /*
-SetGameScore set the score of the specified user in a game. Available options:
+SetGameScore set the score of the specified user in a game and returns edited message.
+With OptDisableEditMessage the message is not edited and nil is returned. Available options:
 	- OptForce
 	- OptDisableEditMessage
 */
@@ -2282,8 +3940,16 @@ func (c *Client) SetGameScore(chatID string, messageID, userID, score int, opts
 	for _, opt := range opts {
 		opt(req)
 	}
+	var result json.RawMessage
+	err := c.doRequest("setGameScore", req, &result)
+	if err != nil {
+		return nil, err
+	}
+	if string(result) == "true" {
+		return nil, nil
+	}
 	msg := &Message{}
-	err := c.doRequest("setGameScore", req, msg)
+	err = c.codec.Unmarshal(result, msg)
 	return msg, err
 }
 
@@ -2312,33 +3978,40 @@ type GameHighScore struct {
 }
 
 /*
-GetGameHighScores get data for high score tables
+GetGameHighScores get data for high score tables, sorted by position
 */
 func (c *Client) GetGameHighScores(chatID string, messageID, userID int) ([]*GameHighScore, error) {
 	req := url.Values{}
 	req.Set("chat_id", chatID)
 	req.Set("message_id", fmt.Sprint(messageID))
 	req.Set("user_id", fmt.Sprint(userID))
-	var scores []*GameHighScore
-	err := c.doRequest("getGameHighScores", req, &scores)
-	return scores, err
+	return c.getGameHighScores(req)
 }
 
 /*
-GetInlineGameHighScores get data for high score tables
+GetInlineGameHighScores get data for high score tables (for inline messages), sorted by position
 */
 func (c *Client) GetInlineGameHighScores(inlineMessageID string, userID int) ([]*GameHighScore, error) {
 	req := url.Values{}
 	req.Set("inline_message_id", inlineMessageID)
 	req.Set("user_id", fmt.Sprint(userID))
+	return c.getGameHighScores(req)
+}
+
+func (c *Client) getGameHighScores(req url.Values) ([]*GameHighScore, error) {
 	var scores []*GameHighScore
 	err := c.doRequest("getGameHighScores", req, &scores)
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].Position < scores[j].Position
+	})
 	return scores, err
 }
 
 /*
 SendPoll sends native telegram poll. Available Options:
 	- OptDisableNotification
+	- OptEffectID(effectID string)
+	- OptBusinessConnectionID(id string)
 	- OptReplyToMessageID(id int)
 	- OptInlineKeyboardMarkup(markup *InlineKeyboardMarkup)
 	- OptReplyKeyboardMarkup(markup *ReplyKeyboardMarkup)
@@ -2351,7 +4024,7 @@ func (c *Client) SendPoll(chatID string, question string, options []string, opts
 	req := url.Values{}
 	req.Set("chat_id", chatID)
 	req.Set("question", question)
-	marshalledOptions, _ := json.Marshal(options)
+	marshalledOptions, _ := c.codec.Marshal(options)
 	req.Set("options", string(marshalledOptions))
 	for _, opt := range opts {
 		opt(req)
@@ -1,10 +1,18 @@
 package tbot_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/yanzay/tbot/v2"
 )
@@ -158,12 +166,834 @@ func TestSendPhotoFile(t *testing.T) {
 	}
 }
 
+func TestGetStickerSet(t *testing.T) {
+	c := testClient(t, `
+		{
+			"ok": true,
+			"result": {"name": "masks", "sticker_type": "mask", "stickers": [{"file_id": "1", "type": "mask"}],
+				"thumbnail": {"file_id": "t", "file_unique_id": "tu", "width": 100, "height": 100}}
+		}
+	`)
+	set, err := c.GetStickerSet("masks")
+	if err != nil {
+		t.Fatalf("error on getStickerSet: %v", err)
+	}
+	if !set.IsMask() {
+		t.Fatalf("expected mask sticker set, got %q", set.StickerType)
+	}
+	if len(set.Stickers) != 1 || set.Stickers[0].Type != tbot.StickerTypeMask {
+		t.Fatalf("unexpected stickers: %+v", set.Stickers)
+	}
+	if set.Thumbnail == nil || set.Thumbnail.FileUniqueID != "tu" {
+		t.Fatalf("unexpected thumbnail: %+v", set.Thumbnail)
+	}
+}
+
+func TestGetUserChatBoosts(t *testing.T) {
+	c := testClient(t, `
+		{
+			"ok": true,
+			"result": {"boosts": [{"boost_id": "b1", "source": {"source": "premium", "user": {"id": 7}}}]}
+		}
+	`)
+	boosts, err := c.GetUserChatBoosts("123", 7)
+	if err != nil {
+		t.Fatalf("error on getUserChatBoosts: %v", err)
+	}
+	if len(boosts.Boosts) != 1 {
+		t.Fatalf("unexpected boosts: %+v", boosts)
+	}
+	src, ok := boosts.Boosts[0].Source.(tbot.ChatBoostSourcePremium)
+	if !ok || src.User.ID != 7 {
+		t.Fatalf("unexpected boost source: %+v", boosts.Boosts[0].Source)
+	}
+}
+
+func TestGetUpdatesChannel(t *testing.T) {
+	c := testClient(t, `{"ok": true, "result": [{"update_id": 10}, {"update_id": 11}]}`)
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, errs := c.GetUpdatesChannel(ctx)
+	up := <-updates
+	if up.UpdateID != 10 {
+		t.Fatalf("unexpected update: %+v", up)
+	}
+	cancel()
+	for range updates {
+	}
+	for range errs {
+	}
+}
+
+func TestBroadcastMessage(t *testing.T) {
+	c := testClient(t, `{"ok": true, "result": {"message_id": 1}}`)
+	errs := c.BroadcastMessage(context.Background(), []string{"1", "2", "3"}, "hello")
+	if len(errs) != 3 {
+		t.Fatalf("unexpected errors count: %d", len(errs))
+	}
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("error on message %d: %v", i, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs = c.BroadcastMessage(ctx, []string{"1", "2"}, "hello")
+	if errs[0] != context.Canceled || errs[1] != context.Canceled {
+		t.Fatalf("expected cancelled errors, got %v", errs)
+	}
+}
+
+func TestDoRaw(t *testing.T) {
+	c := testClient(t, `{"ok":true,"result":{"id":1,"unmapped":"value"}}`)
+	raw, err := c.DoRaw(context.Background(), "getMe", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(raw), `"unmapped":"value"`) {
+		t.Errorf("unexpected response: %s", raw)
+	}
+
+	var methods []string
+	recordMethod := func(next tbot.APIHandler) tbot.APIHandler {
+		return func(ctx context.Context, method string, request url.Values) (json.RawMessage, error) {
+			methods = append(methods, method)
+			return next(ctx, method, request)
+		}
+	}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`)
+	}
+	c = testClientWithHandler(t, handler, tbot.WithClientMiddleware(recordMethod))
+	_, err = c.DoRaw(context.Background(), "getChat", url.Values{"chat_id": {"1"}})
+	if err == nil || err.Error() != "Bad Request: chat not found" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(methods) != 1 || methods[0] != "getChat" {
+		t.Errorf("raw call didn't pass through middleware: %v", methods)
+	}
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("_timeout") != "" {
+			t.Errorf("reserved timeout key sent to API")
+		}
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":1}}`)
+	}
+	c := testClientWithHandler(t, handler, tbot.WithDefaultTimeout(10*time.Millisecond))
+	_, err := c.SendMessage("1", "hi")
+	if err == nil {
+		t.Errorf("expected timeout error")
+	}
+	_, err = c.SendMessage("1", "hi", tbot.OptTimeout(time.Second))
+	if err != nil {
+		t.Errorf("unexpected error with OptTimeout: %v", err)
+	}
+}
+
+func TestFetchChatHistory(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/copyMessages"):
+			if r.FormValue("message_ids") != "[10,11,12]" {
+				t.Errorf("unexpected message ids: %s", r.FormValue("message_ids"))
+			}
+			fmt.Fprint(w, `{"ok":true,"result":[{"message_id":1},{"message_id":2}]}`)
+		case strings.HasSuffix(r.URL.Path, "/forwardMessage"):
+			fmt.Fprintf(w, `{"ok":true,"result":{"message_id":%s0,"text":"copy"}}`, r.FormValue("message_id"))
+		}
+	}
+	c := testClientWithHandler(t, handler)
+	messages, err := c.FetchChatHistory(context.Background(), "-100", "1", 10, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(messages) != 2 || messages[0].MessageID != 10 || messages[1].MessageID != 20 {
+		t.Errorf("unexpected messages: %+v", messages)
+	}
+}
+
+func TestGetMyStarBalance(t *testing.T) {
+	c := testClient(t, `{"ok":true,"result":{"amount":150,"nanostar_amount":500}}`)
+	balance, err := c.GetMyStarBalance()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if balance != 150 {
+		t.Errorf("expected balance 150, got %d", balance)
+	}
+}
+
+func TestCachedGetMe(t *testing.T) {
+	calls := 0
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"ok":true,"result":{"id":1,"username":"tbot"}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	for i := 0; i < 3; i++ {
+		me, err := c.CachedGetMe()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if me.Username != "tbot" {
+			t.Errorf("unexpected user: %+v", me)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 API call, got %d", calls)
+	}
+}
+
+func TestGetFileURL(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok":true,"result":{"file_id":"abc","file_path":"photos/file_1.jpg"}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	fileURL, err := c.GetFileURL("abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(fileURL, "http://") || !strings.HasSuffix(fileURL, "/file/botTOKEN/photos/file_1.jpg") {
+		t.Errorf("unexpected file URL: %s", fileURL)
+	}
+}
+
+func TestSetChatPhotoReader(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		f, _, err := r.FormFile("photo")
+		if err != nil {
+			t.Errorf("photo is not uploaded: %v", err)
+		} else {
+			data, _ := ioutil.ReadAll(f)
+			if string(data) != "PNG" {
+				t.Errorf("unexpected photo data: %q", data)
+			}
+		}
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}
+	c := testClientWithHandler(t, handler)
+	err := c.SetChatPhotoReader("1", strings.NewReader("PNG"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRestrictChatMember(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.FormValue("permissions"), `"can_send_photos":true`) {
+			t.Errorf("unexpected permissions: %s", r.FormValue("permissions"))
+		}
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}
+	c := testClientWithHandler(t, handler)
+	err := c.RestrictChatMember("1", 2, &tbot.ChatPermissions{CanSendMessages: true, CanSendPhotos: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestExportChatInviteLink(t *testing.T) {
+	c := testClient(t, `{"ok":true,"result":{"invite_link":"https://t.me/+abc","creator":{"id":1},"is_primary":false}}`)
+	link, err := c.ExportChatInviteLink("-100")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if link != "https://t.me/+abc" {
+		t.Errorf("unexpected link: %s", link)
+	}
+}
+
+func TestOptDisableWebPagePreview(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("link_preview_options") != `{"is_disabled":true}` {
+			t.Errorf("unexpected link preview options: %s", r.FormValue("link_preview_options"))
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":1}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	_, err := c.SendMessage("1", "https://example.com", tbot.OptDisableWebPagePreview)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetChatAdministratorsWithCache(t *testing.T) {
+	calls := 0
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"ok":true,"result":[{"user":{"id":1},"status":"creator"}]}`)
+	}
+	c := testClientWithHandler(t, handler)
+	for i := 0; i < 2; i++ {
+		admins, err := c.GetChatAdministratorsWithCache("-100", time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(admins) != 1 {
+			t.Fatalf("unexpected admins: %+v", admins)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 API call, got %d", calls)
+	}
+	c.GetChatAdministratorsWithCache("-200", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	c.GetChatAdministratorsWithCache("-200", time.Millisecond)
+	if calls != 3 {
+		t.Errorf("expected expired entry to be refreshed, got %d calls", calls)
+	}
+}
+
+func TestUploadDocument(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		_, header, err := r.FormFile("document")
+		if err != nil {
+			t.Errorf("document is not uploaded: %v", err)
+		} else if header.Filename != "report.csv" {
+			t.Errorf("unexpected filename: %s", header.Filename)
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":1}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	_, err := c.UploadDocument(context.Background(), "1", strings.NewReader("a,b"), "report.csv")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUploadContentType(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		_, header, err := r.FormFile("document")
+		if err != nil {
+			t.Errorf("document is not uploaded: %v", err)
+		} else if ct := header.Header.Get("Content-Type"); ct != "audio/mpeg" {
+			t.Errorf("unexpected content type: %s", ct)
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":1}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	_, err := c.UploadDocument(context.Background(), "1", strings.NewReader("ID3"), "song.mp3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOptFileName(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("_filename") != "" {
+			t.Errorf("reserved filename key sent to API")
+		}
+		_, header, err := r.FormFile("document")
+		if err != nil {
+			t.Errorf("document is not uploaded: %v", err)
+		} else if header.Filename != "report_2024-01-15.pdf" {
+			t.Errorf("unexpected filename: %s", header.Filename)
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":1}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	_, err := c.SendDocumentReader("1", strings.NewReader("%PDF"), tbot.OptFileName("report_2024-01-15.pdf"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReservedKeysNotSent(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unable to parse form: %v", err)
+		}
+		for k := range r.PostForm {
+			if strings.HasPrefix(k, "_") {
+				t.Errorf("reserved key %s sent to API", k)
+			}
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":1}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	_, err := c.SendMessage("1", "hi", tbot.OptFileName("a.txt"), tbot.OptTimeout(time.Second),
+		tbot.OptThumbReader(strings.NewReader("JPEG")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOptThumbReader(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		for _, field := range []string{"audio", "thumbnail"} {
+			if _, _, err := r.FormFile(field); err != nil {
+				t.Errorf("%s is not uploaded: %v", field, err)
+			}
+		}
+		if r.FormValue("_thumbnail") != "" {
+			t.Errorf("reserved thumbnail key sent to API")
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":1}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	_, err := c.SendAudioReader("1", strings.NewReader("ID3"),
+		tbot.OptFileName("song.mp3"), tbot.OptThumbReader(strings.NewReader("JPEG")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c = testClient(t, `{"ok":true,"result":{"message_id":1}}`)
+	_, err = c.SendAudioReader("1", strings.NewReader("ID3"), tbot.OptThumbReader(failingReader{}))
+	if err == nil || !strings.Contains(err.Error(), "broken thumbnail") {
+		t.Errorf("expected thumbnail read error, got %v", err)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, fmt.Errorf("broken thumbnail")
+}
+
+func TestAnswerShippingQueryValidation(t *testing.T) {
+	c := testClient(t, `{"ok":true,"result":true}`)
+	if err := c.AnswerShippingQuery("1", true); err == nil {
+		t.Errorf("expected error without shipping options")
+	}
+	if err := c.AnswerShippingQuery("1", false); err == nil {
+		t.Errorf("expected error without error message")
+	}
+	options := []tbot.ShippingOption{{ID: "post", Title: "Post"}}
+	if err := c.AnswerShippingQuery("1", true, tbot.OptShippingOptions(options)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestNewArticleResult(t *testing.T) {
+	result := tbot.NewArticleResult("1", "Title", "text").WithDescription("desc").WithURL("https://example.com")
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("unable to marshal result: %v", err)
+	}
+	for _, field := range []string{`"type":"article"`, `"message_text":"text"`, `"description":"desc"`} {
+		if !strings.Contains(string(data), field) {
+			t.Errorf("%s is missing in %s", field, data)
+		}
+	}
+}
+
+func TestRegisterCommandsMultiLang(t *testing.T) {
+	langs := make(chan string, 3)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		langs <- r.FormValue("language_code")
+		if r.FormValue("scope") != `{"type":"all_private_chats"}` {
+			t.Errorf("unexpected scope: %s", r.FormValue("scope"))
+		}
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}
+	c := testClientWithHandler(t, handler)
+	commands := map[string][]tbot.BotCommand{
+		"":   {{Command: "start", Description: "Start"}},
+		"uk": {{Command: "start", Description: "Почати"}},
+		"de": {{Command: "start", Description: "Starten"}},
+	}
+	scope := tbot.BotCommandScope{Type: tbot.BotCommandScopeAllPrivateChats}
+	err := tbot.RegisterCommandsMultiLang(context.Background(), c, commands, scope)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	close(langs)
+	var order []string
+	for lang := range langs {
+		order = append(order, lang)
+	}
+	if strings.Join(order, ",") != ",de,uk" {
+		t.Errorf("unexpected registration order: %q", order)
+	}
+}
+
+func TestCopyMessageRemoveKeyboard(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("reply_markup") != `{"inline_keyboard":[]}` {
+			t.Errorf("unexpected reply markup: %s", r.FormValue("reply_markup"))
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":7}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	id, err := c.CopyMessage("1", "2", 3, tbot.OptCopyReplyMarkup(nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id.MessageID != 7 {
+		t.Errorf("unexpected message id: %d", id.MessageID)
+	}
+}
+
+func TestCopyMessagesRemoveCaption(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("remove_caption") != "true" {
+			t.Errorf("remove_caption is not set")
+		}
+		fmt.Fprint(w, `{"ok":true,"result":[{"message_id":7}]}`)
+	}
+	c := testClientWithHandler(t, handler)
+	_, err := c.CopyMessages("1", "2", []int{3}, tbot.OptRemoveCaption)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAddStickerToSetInvalidMaskPosition(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request with invalid mask position is sent")
+	}
+	c := testClientWithHandler(t, handler)
+	positions := []*tbot.MaskPosition{
+		{Point: "nose", Scale: 1},
+		{Point: tbot.MaskPointEyes, Scale: 0},
+		{Point: tbot.MaskPointChin, Scale: 11},
+	}
+	for _, pos := range positions {
+		err := c.AddStickerToSet(1, "set", "file", "x", tbot.OptMaskPosition(pos))
+		if err == nil {
+			t.Errorf("expected error for mask position %+v", pos)
+		}
+	}
+}
+
+func TestRevokeChatInviteLink(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("invite_link") != "https://t.me/+abc" {
+			t.Errorf("unexpected invite link: %s", r.FormValue("invite_link"))
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"invite_link":"https://t.me/+abc","is_revoked":true,"member_limit":5,"pending_join_request_count":2}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	link, err := c.RevokeChatInviteLink("1", "https://t.me/+abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !link.IsRevoked || link.MemberLimit != 5 || link.PendingJoinRequestCount != 2 {
+		t.Errorf("unexpected link: %+v", link)
+	}
+}
+
+func TestSetChatPermissions(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("use_independent_chat_permissions") != "true" {
+			t.Errorf("use_independent_chat_permissions is not set")
+		}
+		if !strings.Contains(r.FormValue("permissions"), `"can_send_videos":true`) {
+			t.Errorf("unexpected permissions: %s", r.FormValue("permissions"))
+		}
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}
+	c := testClientWithHandler(t, handler)
+	err := c.SetChatPermissions("1", &tbot.ChatPermissions{CanSendVideos: true}, tbot.OptUseIndependentChatPermissions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetInlineGameHighScores(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok":true,"result":[
+			{"position":2,"user":{"id":20,"first_name":"Bob","username":"bob"},"score":50},
+			{"position":1,"user":{"id":10,"first_name":"Alice","username":"alice"},"score":90}
+		]}`)
+	}
+	c := testClientWithHandler(t, handler)
+	scores, err := c.GetInlineGameHighScores("inline", 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scores) != 2 || scores[0].Position != 1 || scores[1].Position != 2 {
+		t.Fatalf("scores are not sorted by position: %+v", scores)
+	}
+	if scores[0].User.ID != 10 || scores[0].User.FirstName != "Alice" || scores[0].User.Username != "alice" {
+		t.Errorf("unexpected user: %+v", scores[0].User)
+	}
+}
+
+func TestSetGameScore(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("disable_edit_message") == "true" || r.FormValue("inline_message_id") != "" {
+			fmt.Fprint(w, `{"ok":true,"result":true}`)
+			return
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":5,"chat":{"id":1}}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	msg, err := c.SetGameScore("1", 5, 10, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg == nil || msg.MessageID != 5 {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+	msg, err = c.SetGameScore("1", 5, 10, 100, tbot.OptDisableEditMessage)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg != nil {
+		t.Errorf("expected nil message, got %+v", msg)
+	}
+	err = c.SetInlineGameScore("inline", 10, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDownloadProfilePhoto(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bot" + token + "/getFile":
+			if r.FormValue("file_id") != "big" {
+				t.Errorf("unexpected file id: %s", r.FormValue("file_id"))
+			}
+			fmt.Fprint(w, `{"ok":true,"result":{"file_id":"big","file_path":"photos/file_1.jpg"}}`)
+		case "/file/bot" + token + "/photos/file_1.jpg":
+			fmt.Fprint(w, "photo")
+		default:
+			http.NotFound(w, r)
+		}
+	}
+	c := testClientWithHandler(t, handler)
+	r, err := c.DownloadProfilePhoto(tbot.PhotoSize{FileID: "big", FileUniqueID: "unique"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("unable to read photo: %v", err)
+	}
+	if string(data) != "photo" {
+		t.Errorf("unexpected photo content: %q", data)
+	}
+}
+
+func TestSendStickerReaderEmoji(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("emoji") != "🔥" {
+			t.Errorf("unexpected emoji: %s", r.FormValue("emoji"))
+		}
+		f, header, err := r.FormFile("sticker")
+		if err != nil {
+			t.Errorf("sticker is not uploaded: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer f.Close()
+		if header.Filename != "fire.webp" {
+			t.Errorf("unexpected file name: %s", header.Filename)
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":1}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	_, err := c.SendStickerReader("1", strings.NewReader("webp"), tbot.OptFileName("fire.webp"), tbot.OptStickerEmoji("🔥"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReplaceStickerInSetUpload(t *testing.T) {
+	tmp, err := ioutil.TempFile("", "sticker*.webp")
+	if err != nil {
+		t.Fatalf("unable to create temp file: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	tmp.WriteString("webp")
+	tmp.Close()
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		sticker := &tbot.InputSticker{}
+		err := json.Unmarshal([]byte(r.FormValue("sticker")), sticker)
+		if err != nil {
+			t.Errorf("unable to decode sticker: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if sticker.Sticker != "attach://sticker_file" || sticker.Format != "static" {
+			t.Errorf("unexpected sticker: %+v", sticker)
+		}
+		f, _, err := r.FormFile("sticker_file")
+		if err != nil {
+			t.Errorf("sticker file is not uploaded: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.Close()
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}
+	c := testClientWithHandler(t, handler)
+	err = c.ReplaceStickerInSet(1, "set", "old", tbot.InputSticker{
+		Sticker:   "attach://" + tmp.Name(),
+		Format:    "static",
+		EmojiList: []string{"🔥"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSetStickerPositionInSet(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("position") != "0" {
+			t.Errorf("unexpected position: %s", r.FormValue("position"))
+		}
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}
+	c := testClientWithHandler(t, handler)
+	err := c.SetStickerPositionInSet("sticker", -1)
+	if err == nil {
+		t.Errorf("expected error for negative position")
+	}
+	err = c.MoveStickerToFront("sticker")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAddStickerToSetName(t *testing.T) {
+	var added []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/getMe") {
+			fmt.Fprint(w, `{"ok":true,"result":{"id":1,"is_bot":true,"username":"TestBot"}}`)
+			return
+		}
+		added = append(added, r.FormValue("name"))
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}
+	c := testClientWithHandler(t, handler)
+	err := c.AddStickerToSet(1, "cats", "file", "x")
+	if err == nil {
+		t.Errorf("expected error for set name without bot suffix")
+	}
+	err = c.AddStickerToSet(1, "cats", "file", "x", tbot.OptStickerName("cats_by_testbot"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(added) != 1 || added[0] != "cats_by_testbot" {
+		t.Errorf("unexpected requests: %v", added)
+	}
+}
+
+func TestSetPassportDataErrors(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		expected := `[{"source":"data","type":"passport","field_name":"name","data_hash":"h","message":"wrong name"},` +
+			`{"source":"selfie","type":"passport","file_hash":"s","message":"blurry"},` +
+			`{"source":"files","type":"utility_bill","file_hashes":["a","b"],"message":"expired"}]`
+		if r.FormValue("errors") != expected {
+			t.Errorf("unexpected errors: %s", r.FormValue("errors"))
+		}
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}
+	c := testClientWithHandler(t, handler)
+	err := c.SetPassportDataErrors(1, []tbot.PassportElementError{
+		tbot.NewDataFieldError("passport", "name", "h", "wrong name"),
+		tbot.NewSelfieError("passport", "s", "blurry"),
+		tbot.NewFilesError("utility_bill", []string{"a", "b"}, "expired"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCopyMessageFrom(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("from_chat_id") != "-100" || r.FormValue("message_id") != "42" {
+			t.Errorf("unexpected source: %s %s", r.FormValue("from_chat_id"), r.FormValue("message_id"))
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":7}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	msg := &tbot.Message{MessageID: 42, Chat: tbot.Chat{ID: "-100"}}
+	_, err := c.CopyMessageFrom("1", msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestForwardMessageFrom(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("from_chat_id") != "-100" || r.FormValue("message_id") != "42" {
+			t.Errorf("unexpected source: %s %s", r.FormValue("from_chat_id"), r.FormValue("message_id"))
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":7,"chat":{"id":1}}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	msg := &tbot.Message{MessageID: 42, Chat: tbot.Chat{ID: "-100"}}
+	forwarded, err := c.ForwardMessageFrom("1", msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if forwarded.MessageID != 7 {
+		t.Errorf("unexpected message id: %d", forwarded.MessageID)
+	}
+}
+
+func TestReplyToMessage(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("chat_id") != "-100" || r.FormValue("reply_to_message_id") != "42" || r.FormValue("text") != "pong" {
+			t.Errorf("unexpected request: %v", r.Form)
+		}
+		fmt.Fprint(w, `{"ok":true,"result":{"message_id":43,"chat":{"id":-100}}}`)
+	}
+	c := testClientWithHandler(t, handler)
+	msg := &tbot.Message{MessageID: 42, Chat: tbot.Chat{ID: "-100"}}
+	_, err := c.ReplyToMessage(msg, "pong", tbot.OptDisableNotification)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBulkDeleteMessages(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]int
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var ids []int
+		err := json.Unmarshal([]byte(r.FormValue("message_ids")), &ids)
+		if err != nil {
+			t.Errorf("unable to decode message ids: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		batches = append(batches, ids)
+		mu.Unlock()
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}
+	c := testClientWithHandler(t, handler)
+	err := c.BulkDeleteMessages(context.Background(), "1", 10, 259)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mu.Lock()
+	got := batches
+	mu.Unlock()
+	if len(got) != 3 || len(got[0]) != 100 || len(got[2]) != 50 {
+		t.Fatalf("unexpected batches: %d", len(got))
+	}
+	if got[0][0] != 10 || got[2][49] != 259 {
+		t.Errorf("unexpected range: %d..%d", got[0][0], got[2][49])
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = c.BulkDeleteMessages(ctx, "1", 1, 10)
+	if err != context.Canceled {
+		t.Errorf("expected context canceled error, got %v", err)
+	}
+}
+
 func testClient(t *testing.T, resp string) *tbot.Client {
 	t.Helper()
-	handler := func(w http.ResponseWriter, r *http.Request) {
+	return testClientWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, resp)
-	}
-	httpServer := httptest.NewServer(http.HandlerFunc(handler))
-	httpClient := httpServer.Client()
-	return tbot.NewClient(token, httpClient, httpServer.URL)
+	})
+}
+
+// testClientWithHandler returns client talking to test server serving requests with handler
+func testClientWithHandler(t *testing.T, handler http.HandlerFunc, options ...tbot.ClientOption) *tbot.Client {
+	t.Helper()
+	httpServer := httptest.NewServer(handler)
+	t.Cleanup(httpServer.Close)
+	return tbot.NewClient(token, httpServer.Client(), httpServer.URL, options...)
 }
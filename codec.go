@@ -0,0 +1,34 @@
+package tbot
+
+import "encoding/json"
+
+// JSONCodec encodes request parameters and decodes API responses.
+// It allows to replace encoding/json with faster compatible implementation.
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type stdJSONCodec struct{}
+
+func (stdJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (stdJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// WithJSONCodec sets JSONCodec used by the client, encoding/json by default.
+// Parameters set by send options are always encoded with encoding/json.
+func WithJSONCodec(codec JSONCodec) ClientOption {
+	return func(c *Client) {
+		c.codec = codec
+	}
+}
+
+// marshalString is structString using client codec
+func (c *Client) marshalString(v interface{}) string {
+	str, _ := c.codec.Marshal(v)
+	return string(str)
+}
@@ -0,0 +1,42 @@
+package tbot_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+type countingCodec struct {
+	marshals, unmarshals int
+}
+
+func (c *countingCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshals++
+	return json.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshals++
+	return json.Unmarshal(data, v)
+}
+
+func TestJSONCodec(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("results") == "" {
+			t.Errorf("results are not sent")
+		}
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}
+	codec := &countingCodec{}
+	c := testClientWithHandler(t, handler, tbot.WithJSONCodec(codec))
+	err := c.AnswerInlineQuery("q", []tbot.InlineQueryResult{tbot.NewArticleResult("1", "a", "text")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if codec.marshals != 1 || codec.unmarshals != 2 {
+		t.Fatalf("unexpected codec calls: %d marshals, %d unmarshals", codec.marshals, codec.unmarshals)
+	}
+}
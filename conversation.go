@@ -0,0 +1,99 @@
+package tbot
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ConversationStore keeps current conversation step for every chat.
+// Implement it to persist conversations, e.g. in Redis.
+type ConversationStore interface {
+	// Get returns current step of the chat or empty string if there is no conversation
+	Get(chatID string) (string, error)
+	// Set saves current step of the chat, empty step ends the conversation
+	Set(chatID, step string) error
+}
+
+// memoryStore is the default in-memory ConversationStore
+type memoryStore struct {
+	steps sync.Map
+}
+
+func (s *memoryStore) Get(chatID string) (string, error) {
+	step, ok := s.steps.Load(chatID)
+	if !ok {
+		return "", nil
+	}
+	return step.(string), nil
+}
+
+func (s *memoryStore) Set(chatID, step string) error {
+	if step == "" {
+		s.steps.Delete(chatID)
+		return nil
+	}
+	s.steps.Store(chatID, step)
+	return nil
+}
+
+// ConversationHandler handles update on conversation step and returns the next step.
+// Empty next step ends the conversation.
+type ConversationHandler func(*Update) (nextStep string, err error)
+
+// Conversation is a state machine for multi-step interactions in chats
+type Conversation struct {
+	store ConversationStore
+	first string
+	steps map[string]ConversationHandler
+}
+
+// NewConversation creates Conversation with in-memory state store
+func NewConversation() *Conversation {
+	return &Conversation{
+		store: &memoryStore{},
+		steps: make(map[string]ConversationHandler),
+	}
+}
+
+// WithStore replaces state store of the conversation
+func (c *Conversation) WithStore(store ConversationStore) *Conversation {
+	c.store = store
+	return c
+}
+
+// Step registers handler for the named step. The first registered step starts the conversation.
+func (c *Conversation) Step(name string, handler ConversationHandler) *Conversation {
+	if c.first == "" {
+		c.first = name
+	}
+	c.steps[name] = handler
+	return c
+}
+
+// Start begins the conversation in the chat from the first step
+func (c *Conversation) Start(chatID string) error {
+	return c.store.Set(chatID, c.first)
+}
+
+// Handle passes update to the current step of its chat conversation.
+// Updates from chats without conversation are ignored.
+// If step handler returns error, the conversation stays on the same step.
+func (c *Conversation) Handle(u *Update) error {
+	chatID := u.ChatID()
+	if chatID == "" {
+		return nil
+	}
+	step, err := c.store.Get(chatID)
+	if err != nil || step == "" {
+		return err
+	}
+	handler, ok := c.steps[step]
+	if !ok {
+		return fmt.Errorf("unknown conversation step %q", step)
+	}
+	next, err := handler(u)
+	if err != nil {
+		return err
+	}
+	return c.store.Set(chatID, next)
+}
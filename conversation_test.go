@@ -0,0 +1,39 @@
+package tbot_test
+
+import (
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestConversation(t *testing.T) {
+	var name, city string
+	conv := tbot.NewConversation().
+		Step("name", func(u *tbot.Update) (string, error) {
+			name = u.Text()
+			return "city", nil
+		}).
+		Step("city", func(u *tbot.Update) (string, error) {
+			city = u.Text()
+			return "", nil
+		})
+	message := func(text string) *tbot.Update {
+		return &tbot.Update{Message: &tbot.Message{Text: text, Chat: tbot.Chat{ID: "1"}}}
+	}
+
+	conv.Handle(message("ignored"))
+	if name != "" {
+		t.Fatalf("update handled before conversation start")
+	}
+	if err := conv.Start("1"); err != nil {
+		t.Fatalf("unable to start conversation: %v", err)
+	}
+	for _, text := range []string{"Alice", "Berlin", "ignored"} {
+		if err := conv.Handle(message(text)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if name != "Alice" || city != "Berlin" {
+		t.Fatalf("unexpected answers: %s, %s", name, city)
+	}
+}
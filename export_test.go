@@ -0,0 +1,8 @@
+package tbot
+
+import "net/http"
+
+// HTTPTransport exposes transport of the client to tests
+func HTTPTransport(c *Client) http.RoundTripper {
+	return c.httpClient.Transport
+}
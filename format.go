@@ -0,0 +1,94 @@
+package tbot
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	htmlEscaper       = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	htmlAttrEscaper   = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+	markdownV2Escaper = strings.NewReplacer(
+		`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+		"~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`,
+		"|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+	)
+	markdownV2CodeEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`")
+	markdownV2URLEscaper  = strings.NewReplacer(`\`, `\\`, ")", `\)`)
+)
+
+// EscapeHTML escapes <, > and & for messages sent with ParseModeHTML.
+// Unlike html.EscapeString quotes are left as is.
+func EscapeHTML(s string) string {
+	return htmlEscaper.Replace(s)
+}
+
+// EscapeMarkdownV2 escapes all special characters for messages sent with ParseModeMarkdownV2
+func EscapeMarkdownV2(s string) string {
+	return markdownV2Escaper.Replace(s)
+}
+
+// Formatting helpers below escape given text for the parse mode,
+// so it must be passed raw. ParseModeMarkdown has no escaping inside entities
+// and text is used as is.
+
+// Bold returns text formatted as bold
+func Bold(text string, mode ParseMode) string {
+	return wrap(text, mode, "*", "<b>", "</b>")
+}
+
+// Italic returns text formatted as italic
+func Italic(text string, mode ParseMode) string {
+	return wrap(text, mode, "_", "<i>", "</i>")
+}
+
+// Code returns text formatted as inline fixed-width code
+func Code(text string, mode ParseMode) string {
+	switch mode {
+	case ParseModeHTML:
+		return "<code>" + EscapeHTML(text) + "</code>"
+	case ParseModeMarkdownV2:
+		return "`" + markdownV2CodeEscaper.Replace(text) + "`"
+	}
+	return "`" + text + "`"
+}
+
+// Pre returns text formatted as pre-formatted code block in given language, lang may be empty
+func Pre(text, lang string, mode ParseMode) string {
+	switch mode {
+	case ParseModeHTML:
+		if lang == "" {
+			return "<pre>" + EscapeHTML(text) + "</pre>"
+		}
+		return fmt.Sprintf(`<pre><code class="language-%s">%s</code></pre>`, htmlAttrEscaper.Replace(lang), EscapeHTML(text))
+	case ParseModeMarkdownV2:
+		return "```" + lang + "\n" + markdownV2CodeEscaper.Replace(text) + "\n```"
+	}
+	return "```" + lang + "\n" + text + "\n```"
+}
+
+// Link returns text formatted as inline link to url
+func Link(text, url string, mode ParseMode) string {
+	switch mode {
+	case ParseModeHTML:
+		return fmt.Sprintf(`<a href="%s">%s</a>`, htmlAttrEscaper.Replace(url), EscapeHTML(text))
+	case ParseModeMarkdownV2:
+		return "[" + EscapeMarkdownV2(text) + "](" + markdownV2URLEscaper.Replace(url) + ")"
+	}
+	return "[" + text + "](" + url + ")"
+}
+
+// Mention returns text formatted as inline mention of the user
+func Mention(text string, userID int, mode ParseMode) string {
+	return Link(text, fmt.Sprintf("tg://user?id=%d", userID), mode)
+}
+
+func wrap(text string, mode ParseMode, markdown, htmlOpen, htmlClose string) string {
+	switch mode {
+	case ParseModeHTML:
+		return htmlOpen + EscapeHTML(text) + htmlClose
+	case ParseModeMarkdownV2:
+		return markdown + EscapeMarkdownV2(text) + markdown
+	}
+	return markdown + text + markdown
+}
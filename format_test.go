@@ -0,0 +1,34 @@
+package tbot_test
+
+import (
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestEscapeHTML(t *testing.T) {
+	got := tbot.EscapeHTML(`<b>"Tom" & 'Jerry'</b>`)
+	expected := `&lt;b&gt;"Tom" &amp; 'Jerry'&lt;/b&gt;`
+	if got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestFormatting(t *testing.T) {
+	cases := []struct {
+		got, expected string
+	}{
+		{tbot.Bold("1+1=2", tbot.ParseModeMarkdownV2), `*1\+1\=2*`},
+		{tbot.Bold("a<b", tbot.ParseModeHTML), "<b>a&lt;b</b>"},
+		{tbot.Italic("hi", tbot.ParseModeMarkdown), "_hi_"},
+		{tbot.Code("a`b", tbot.ParseModeMarkdownV2), "`a\\`b`"},
+		{tbot.Pre("x := 1", "go", tbot.ParseModeHTML), `<pre><code class="language-go">x := 1</code></pre>`},
+		{tbot.Link("site.", "https://example.com/(a)", tbot.ParseModeMarkdownV2), `[site\.](https://example.com/(a\))`},
+		{tbot.Mention("Bob", 42, tbot.ParseModeHTML), `<a href="tg://user?id=42">Bob</a>`},
+	}
+	for _, c := range cases {
+		if c.got != c.expected {
+			t.Errorf("expected %s, got %s", c.expected, c.got)
+		}
+	}
+}
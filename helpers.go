@@ -1,5 +1,15 @@
 package tbot
 
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
 // Buttons construct ReplyKeyboardMarkup from strings
 func Buttons(buttons [][]string) *ReplyKeyboardMarkup {
 	keyboard := make([][]KeyboardButton, len(buttons))
@@ -11,3 +21,173 @@ func Buttons(buttons [][]string) *ReplyKeyboardMarkup {
 	}
 	return &ReplyKeyboardMarkup{Keyboard: keyboard}
 }
+
+// NewPaginatedKeyboard builds inline keyboard with one button per item on the current page
+// and navigation row. Item buttons send callbackPrefix:item:<n> with index of the item in items,
+// as item text may exceed 64 bytes limit of callback data. Navigation buttons send
+// callbackPrefix:prev:<page> and callbackPrefix:next:<page> with the page to show.
+// Pages are zero-based, out of range page is clamped.
+func NewPaginatedKeyboard(items []string, pageSize, currentPage int, callbackPrefix string) *InlineKeyboardMarkup {
+	if pageSize <= 0 {
+		pageSize = len(items)
+	}
+	lastPage := 0
+	if pageSize > 0 && len(items) > 0 {
+		lastPage = (len(items) - 1) / pageSize
+	}
+	if currentPage < 0 {
+		currentPage = 0
+	}
+	if currentPage > lastPage {
+		currentPage = lastPage
+	}
+	var keyboard [][]InlineKeyboardButton
+	start := currentPage * pageSize
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	for i := start; i < end; i++ {
+		keyboard = append(keyboard, []InlineKeyboardButton{
+			{Text: items[i], CallbackData: fmt.Sprintf("%s:item:%d", callbackPrefix, i)},
+		})
+	}
+	var nav []InlineKeyboardButton
+	if currentPage > 0 {
+		nav = append(nav, InlineKeyboardButton{
+			Text:         "« Prev",
+			CallbackData: fmt.Sprintf("%s:prev:%d", callbackPrefix, currentPage-1),
+		})
+	}
+	if currentPage < lastPage {
+		nav = append(nav, InlineKeyboardButton{
+			Text:         "Next »",
+			CallbackData: fmt.Sprintf("%s:next:%d", callbackPrefix, currentPage+1),
+		})
+	}
+	if len(nav) > 0 {
+		keyboard = append(keyboard, nav)
+	}
+	return &InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// maxReactionsPerRow is the number of emoji buttons in a row of NewReactionKeyboard
+const maxReactionsPerRow = 8
+
+// NewReactionKeyboard builds inline keyboard with a button for every emoji,
+// at most 8 buttons in a row. Buttons send callbackPrefix:<n> with index of the emoji in emojis,
+// so callback data fits 64 bytes limit for any emoji.
+func NewReactionKeyboard(emojis []string, callbackPrefix string) *InlineKeyboardMarkup {
+	var keyboard [][]InlineKeyboardButton
+	for i := 0; i < len(emojis); i += maxReactionsPerRow {
+		end := i + maxReactionsPerRow
+		if end > len(emojis) {
+			end = len(emojis)
+		}
+		row := make([]InlineKeyboardButton, 0, end-i)
+		for j := i; j < end; j++ {
+			row = append(row, InlineKeyboardButton{Text: emojis[j], CallbackData: fmt.Sprintf("%s:%d", callbackPrefix, j)})
+		}
+		keyboard = append(keyboard, row)
+	}
+	return &InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+var deepLinkPayloadRx = regexp.MustCompile(`^[A-Za-z0-9_-]*$`)
+
+// DeepLink returns link starting private chat with the bot with given start payload.
+// Payload is limited to 64 characters: A-Z, a-z, 0-9, _ and -.
+func DeepLink(botUsername, payload string) (string, error) {
+	return deepLink(botUsername, "start", payload)
+}
+
+// GroupDeepLink returns link adding the bot to a group with given start payload.
+// Payload is limited to 64 characters: A-Z, a-z, 0-9, _ and -.
+func GroupDeepLink(botUsername, payload string) (string, error) {
+	return deepLink(botUsername, "startgroup", payload)
+}
+
+func deepLink(botUsername, param, payload string) (string, error) {
+	if len(payload) > 64 {
+		return "", fmt.Errorf("deep link payload is longer than 64 characters: %d", len(payload))
+	}
+	if !deepLinkPayloadRx.MatchString(payload) {
+		return "", fmt.Errorf("deep link payload contains invalid characters: %q", payload)
+	}
+	return fmt.Sprintf("https://t.me/%s?%s=%s", strings.TrimPrefix(botUsername, "@"), param, payload), nil
+}
+
+// MaxMessageLength is the maximum length of a text message in characters
+const MaxMessageLength = 4096
+
+// PaginateText splits text into pages of at most maxLen characters (runes),
+// breaking at whitespace where possible. Zero or negative maxLen means MaxMessageLength.
+func PaginateText(text string, maxLen int) []string {
+	if maxLen <= 0 {
+		maxLen = MaxMessageLength
+	}
+	runes := []rune(text)
+	var pages []string
+	for len(runes) > maxLen {
+		cut := -1
+		for i := maxLen; i > 0; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+		if cut < 0 {
+			pages = append(pages, string(runes[:maxLen]))
+			runes = runes[maxLen:]
+			continue
+		}
+		pages = append(pages, string(runes[:cut]))
+		runes = runes[cut+1:]
+	}
+	return append(pages, string(runes))
+}
+
+// DiffPromotions compares admin rights before and after a change.
+// granted has true for rights changed from false to true,
+// revoked has true for rights changed from true to false.
+func DiffPromotions(before, after Promotions) (granted, revoked Promotions) {
+	b := reflect.ValueOf(before)
+	a := reflect.ValueOf(after)
+	g := reflect.ValueOf(&granted).Elem()
+	r := reflect.ValueOf(&revoked).Elem()
+	for i := 0; i < b.NumField(); i++ {
+		if b.Field(i).Kind() != reflect.Bool {
+			continue
+		}
+		was, is := b.Field(i).Bool(), a.Field(i).Bool()
+		g.Field(i).SetBool(!was && is)
+		r.Field(i).SetBool(was && !is)
+	}
+	return granted, revoked
+}
+
+// RegisterCommandsMultiLang sets bot commands for every language code in commandsByLang,
+// empty language code sets default commands. Empty scope type means default scope.
+// Languages are registered in sorted order, default commands first. It stops on the first error.
+func RegisterCommandsMultiLang(ctx context.Context, c *Client, commandsByLang map[string][]BotCommand, scope BotCommandScope) error {
+	langs := make([]string, 0, len(commandsByLang))
+	for lang := range commandsByLang {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs) // default "" goes first
+	for _, lang := range langs {
+		commands := commandsByLang[lang]
+		opts := []sendOption{}
+		if lang != "" {
+			opts = append(opts, OptLanguageCode(lang))
+		}
+		if scope.Type != "" {
+			opts = append(opts, OptCommandScope(scope))
+		}
+		err := c.setMyCommands(ctx, commands, opts...)
+		if err != nil {
+			return fmt.Errorf("unable to set commands for language %q: %v", lang, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package tbot_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestDeepLink(t *testing.T) {
+	link, err := tbot.DeepLink("@test_bot", "ref_42")
+	if err != nil {
+		t.Fatalf("error on deep link: %v", err)
+	}
+	if link != "https://t.me/test_bot?start=ref_42" {
+		t.Fatalf("unexpected link: %s", link)
+	}
+	link, err = tbot.GroupDeepLink("test_bot", "abc")
+	if err != nil || link != "https://t.me/test_bot?startgroup=abc" {
+		t.Fatalf("unexpected group link: %s, %v", link, err)
+	}
+	if _, err = tbot.DeepLink("test_bot", "a b"); err == nil {
+		t.Fatalf("expected error for invalid characters")
+	}
+	if _, err = tbot.DeepLink("test_bot", strings.Repeat("a", 65)); err == nil {
+		t.Fatalf("expected error for long payload")
+	}
+}
+
+func TestPaginateText(t *testing.T) {
+	pages := tbot.PaginateText("привет мир как дела", 10)
+	expected := []string{"привет мир", "как дела"}
+	if strings.Join(pages, "|") != strings.Join(expected, "|") {
+		t.Fatalf("unexpected pages: %q", pages)
+	}
+	pages = tbot.PaginateText("abcdefghij", 4)
+	if strings.Join(pages, "|") != "abcd|efgh|ij" {
+		t.Fatalf("unexpected pages without spaces: %q", pages)
+	}
+	if pages = tbot.PaginateText("short", 0); len(pages) != 1 || pages[0] != "short" {
+		t.Fatalf("unexpected pages for short text: %q", pages)
+	}
+}
+
+func TestDiffPromotions(t *testing.T) {
+	before := tbot.Promotions{CanDeleteMessages: true, CanInviteUsers: true}
+	after := tbot.Promotions{CanInviteUsers: true, CanPinMessages: true}
+	granted, revoked := tbot.DiffPromotions(before, after)
+	if granted != (tbot.Promotions{CanPinMessages: true}) {
+		t.Errorf("unexpected granted rights: %+v", granted)
+	}
+	if revoked != (tbot.Promotions{CanDeleteMessages: true}) {
+		t.Errorf("unexpected revoked rights: %+v", revoked)
+	}
+}
+
+func TestNewPaginatedKeyboard(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	first := tbot.NewPaginatedKeyboard(items, 2, 0, "menu").InlineKeyboard
+	if len(first) != 3 || first[0][0].CallbackData != "menu:item:0" {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+	if nav := first[2]; len(nav) != 1 || nav[0].CallbackData != "menu:next:1" {
+		t.Fatalf("unexpected first page navigation: %+v", nav)
+	}
+	middle := tbot.NewPaginatedKeyboard(items, 2, 1, "menu").InlineKeyboard
+	if nav := middle[2]; len(nav) != 2 || nav[0].CallbackData != "menu:prev:0" || nav[1].CallbackData != "menu:next:2" {
+		t.Fatalf("unexpected middle page navigation: %+v", nav)
+	}
+	last := tbot.NewPaginatedKeyboard(items, 2, 2, "menu").InlineKeyboard
+	if len(last) != 2 || last[0][0].Text != "e" || last[0][0].CallbackData != "menu:item:4" {
+		t.Fatalf("unexpected last page: %+v", last)
+	}
+	if nav := last[1]; len(nav) != 1 || nav[0].CallbackData != "menu:prev:1" {
+		t.Fatalf("unexpected last page navigation: %+v", nav)
+	}
+}
+
+func TestNewReactionKeyboard(t *testing.T) {
+	emojis := strings.Split("👍 👎 😂 😍 😢 😡 🔥 🎉 🤔 👀", " ")
+	rows := tbot.NewReactionKeyboard(emojis, "vote").InlineKeyboard
+	if len(rows) != 2 || len(rows[0]) != 8 || len(rows[1]) != 2 {
+		t.Fatalf("unexpected rows: %+v", rows)
+	}
+	if rows[1][1].Text != "👀" || rows[1][1].CallbackData != "vote:9" {
+		t.Fatalf("unexpected button: %+v", rows[1][1])
+	}
+}
@@ -0,0 +1,93 @@
+package tbot
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// inlineQueryTimeout is the time Telegram waits for inline query answer
+	inlineQueryTimeout = 10 * time.Second
+	// inlineQueryAnswerMargin is the time before deadline when empty answer is sent
+	inlineQueryAnswerMargin = 500 * time.Millisecond
+)
+
+// ErrInlineQueryAnswered is returned by InlineQueryContext.Answer when the query is already answered
+var ErrInlineQueryAnswered = errors.New("inline query is already answered")
+
+// InlineQueryContext helps to answer inline query in time.
+// If Answer isn't called, empty answer is sent 0.5 seconds before the deadline,
+// unless the context of the query is cancelled.
+type InlineQueryContext struct {
+	Query  string
+	From   User
+	Offset string
+
+	client   *Client
+	id       string
+	timer    *time.Timer
+	done     chan struct{}
+	mu       sync.Mutex
+	answered bool
+}
+
+// NewInlineQueryContext creates InlineQueryContext for the query.
+// Deadline is taken from ctx, but it is never later than Telegram's 10 seconds timeout.
+func NewInlineQueryContext(ctx context.Context, client *Client, q *InlineQuery) *InlineQueryContext {
+	limit := time.Now().Add(inlineQueryTimeout)
+	deadline, ok := ctx.Deadline()
+	if !ok || deadline.After(limit) {
+		deadline = limit
+	}
+	iqc := &InlineQueryContext{
+		Query:  q.Query,
+		Offset: q.Offset,
+		client: client,
+		id:     q.ID,
+		done:   make(chan struct{}),
+	}
+	if q.From != nil {
+		iqc.From = *q.From
+	}
+	iqc.timer = time.AfterFunc(time.Until(deadline)-inlineQueryAnswerMargin, func() {
+		err := iqc.answer([]InlineQueryResult{})
+		if err != nil && err != ErrInlineQueryAnswered {
+			client.logger.Errorf("unable to answer inline query %s: %v", q.ID, err)
+		}
+	})
+	go func() {
+		select {
+		case <-ctx.Done():
+			iqc.timer.Stop()
+		case <-iqc.done:
+		}
+	}()
+	return iqc
+}
+
+/*
+Answer sends results for the inline query, it may be called only once. Available Options:
+	- OptCacheTime(d *time.Duration)
+	- OptIsPersonal
+	- OptNextOffset(offset string)
+	- OptSwitchPmText(text string)
+	- OptSwitchPmParameter(param string)
+*/
+func (iqc *InlineQueryContext) Answer(results []InlineQueryResult, opts ...sendOption) error {
+	iqc.timer.Stop()
+	return iqc.answer(results, opts...)
+}
+
+func (iqc *InlineQueryContext) answer(results []InlineQueryResult, opts ...sendOption) error {
+	iqc.mu.Lock()
+	if iqc.answered {
+		iqc.mu.Unlock()
+		return ErrInlineQueryAnswered
+	}
+	iqc.answered = true
+	close(iqc.done)
+	iqc.mu.Unlock()
+	return iqc.client.AnswerInlineQuery(iqc.id, results, opts...)
+}
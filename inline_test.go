@@ -0,0 +1,53 @@
+package tbot_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestInlineQueryContextAutoAnswer(t *testing.T) {
+	answers := make(chan string, 1)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		answers <- r.FormValue("results")
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}
+	c := testClientWithHandler(t, handler)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 600*time.Millisecond)
+	defer cancel()
+	iqc := tbot.NewInlineQueryContext(ctx, c, &tbot.InlineQuery{ID: "q", Query: "cats"})
+	select {
+	case results := <-answers:
+		if results != "[]" {
+			t.Errorf("unexpected auto answer: %s", results)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("inline query is not answered before deadline")
+	}
+	if err := iqc.Answer(nil); err != tbot.ErrInlineQueryAnswered {
+		t.Errorf("expected ErrInlineQueryAnswered, got %v", err)
+	}
+}
+
+func TestInlineQueryContextCancel(t *testing.T) {
+	answers := make(chan string, 1)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		answers <- r.FormValue("results")
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}
+	c := testClientWithHandler(t, handler)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 600*time.Millisecond)
+	tbot.NewInlineQueryContext(ctx, c, &tbot.InlineQuery{ID: "q", Query: "cats"})
+	cancel()
+	select {
+	case results := <-answers:
+		t.Errorf("query is answered after cancellation: %s", results)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
@@ -1,6 +1,19 @@
 package tbot
 
-import "log"
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// Log levels passed to Logger.Log
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
 
 // Logger defines interface for any compatible logger
 type Logger interface {
@@ -15,20 +28,24 @@ type Logger interface {
 	Print(args ...interface{})
 	Warn(args ...interface{})
 	Error(args ...interface{})
+
+	// Log writes structured message with given level and fields
+	Log(level string, message string, fields map[string]interface{})
 }
 
 type nopLogger struct{}
 
-func (nopLogger) Debugf(format string, args ...interface{}) {}
-func (nopLogger) Infof(format string, args ...interface{})  {}
-func (nopLogger) Printf(format string, args ...interface{}) {}
-func (nopLogger) Warnf(format string, args ...interface{})  {}
-func (nopLogger) Errorf(format string, args ...interface{}) {}
-func (nopLogger) Debug(args ...interface{})                 {}
-func (nopLogger) Info(args ...interface{})                  {}
-func (nopLogger) Print(args ...interface{})                 {}
-func (nopLogger) Warn(args ...interface{})                  {}
-func (nopLogger) Error(args ...interface{})                 {}
+func (nopLogger) Debugf(format string, args ...interface{})                       {}
+func (nopLogger) Infof(format string, args ...interface{})                        {}
+func (nopLogger) Printf(format string, args ...interface{})                       {}
+func (nopLogger) Warnf(format string, args ...interface{})                        {}
+func (nopLogger) Errorf(format string, args ...interface{})                       {}
+func (nopLogger) Debug(args ...interface{})                                       {}
+func (nopLogger) Info(args ...interface{})                                        {}
+func (nopLogger) Print(args ...interface{})                                       {}
+func (nopLogger) Warn(args ...interface{})                                        {}
+func (nopLogger) Error(args ...interface{})                                       {}
+func (nopLogger) Log(level string, message string, fields map[string]interface{}) {}
 
 type BasicLogger struct{}
 
@@ -42,3 +59,44 @@ func (BasicLogger) Info(args ...interface{})                  { log.Print(args..
 func (BasicLogger) Print(args ...interface{})                 { log.Print(args...) }
 func (BasicLogger) Warn(args ...interface{})                  { log.Print(args...) }
 func (BasicLogger) Error(args ...interface{})                 { log.Print(args...) }
+func (BasicLogger) Log(level string, message string, fields map[string]interface{}) {
+	log.Print(formatLog(level, message, fields))
+}
+
+// NewStdLogger returns Logger writing to standard library logger
+func NewStdLogger(l *log.Logger) Logger {
+	return stdLogger{l}
+}
+
+type stdLogger struct {
+	l *log.Logger
+}
+
+func (s stdLogger) Debugf(format string, args ...interface{}) { s.l.Printf(format, args...) }
+func (s stdLogger) Infof(format string, args ...interface{})  { s.l.Printf(format, args...) }
+func (s stdLogger) Printf(format string, args ...interface{}) { s.l.Printf(format, args...) }
+func (s stdLogger) Warnf(format string, args ...interface{})  { s.l.Printf(format, args...) }
+func (s stdLogger) Errorf(format string, args ...interface{}) { s.l.Printf(format, args...) }
+func (s stdLogger) Debug(args ...interface{})                 { s.l.Print(args...) }
+func (s stdLogger) Info(args ...interface{})                  { s.l.Print(args...) }
+func (s stdLogger) Print(args ...interface{})                 { s.l.Print(args...) }
+func (s stdLogger) Warn(args ...interface{})                  { s.l.Print(args...) }
+func (s stdLogger) Error(args ...interface{})                 { s.l.Print(args...) }
+func (s stdLogger) Log(level string, message string, fields map[string]interface{}) {
+	s.l.Print(formatLog(level, message, fields))
+}
+
+// formatLog renders structured message as "[level] message key=value ..." with sorted keys
+func formatLog(level string, message string, fields map[string]interface{}) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "[%s] %s", level, message)
+	for _, k := range keys {
+		fmt.Fprintf(b, " %s=%v", k, fields[k])
+	}
+	return b.String()
+}
@@ -0,0 +1,55 @@
+//go:build go1.21
+// +build go1.21
+
+package tbot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// NewSlogLogger returns Logger writing to slog logger
+func NewSlogLogger(l *slog.Logger) Logger {
+	return slogLogger{l}
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (s slogLogger) Debugf(format string, args ...interface{}) {
+	s.l.Debug(fmt.Sprintf(format, args...))
+}
+func (s slogLogger) Infof(format string, args ...interface{}) { s.l.Info(fmt.Sprintf(format, args...)) }
+func (s slogLogger) Printf(format string, args ...interface{}) {
+	s.l.Info(fmt.Sprintf(format, args...))
+}
+func (s slogLogger) Warnf(format string, args ...interface{}) { s.l.Warn(fmt.Sprintf(format, args...)) }
+func (s slogLogger) Errorf(format string, args ...interface{}) {
+	s.l.Error(fmt.Sprintf(format, args...))
+}
+func (s slogLogger) Debug(args ...interface{}) { s.l.Debug(fmt.Sprint(args...)) }
+func (s slogLogger) Info(args ...interface{})  { s.l.Info(fmt.Sprint(args...)) }
+func (s slogLogger) Print(args ...interface{}) { s.l.Info(fmt.Sprint(args...)) }
+func (s slogLogger) Warn(args ...interface{})  { s.l.Warn(fmt.Sprint(args...)) }
+func (s slogLogger) Error(args ...interface{}) { s.l.Error(fmt.Sprint(args...)) }
+func (s slogLogger) Log(level string, message string, fields map[string]interface{}) {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for k, v := range fields {
+		attrs = append(attrs, slog.Any(k, v))
+	}
+	s.l.LogAttrs(context.Background(), slogLevel(level), message, attrs...)
+}
+
+func slogLevel(level string) slog.Level {
+	switch level {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
@@ -0,0 +1,19 @@
+package tbot_test
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestStdLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := tbot.NewStdLogger(log.New(buf, "", 0))
+	logger.Log(tbot.LevelWarn, "retrying", map[string]interface{}{"method": "sendMessage", "attempt": 2})
+	expected := "[warn] retrying attempt=2 method=sendMessage\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
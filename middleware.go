@@ -0,0 +1,188 @@
+package tbot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// APIHandler performs Telegram API call and returns raw result
+type APIHandler func(ctx context.Context, method string, request url.Values) (json.RawMessage, error)
+
+// ClientMiddleware is a middleware for API calls, including file uploads.
+// Only form fields of the upload are passed in request, files are sent by the innermost handler.
+type ClientMiddleware func(APIHandler) APIHandler
+
+// WithClientMiddleware adds middlewares for API calls, first one is the outermost
+func WithClientMiddleware(m ...ClientMiddleware) ClientOption {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, m...)
+	}
+}
+
+// ErrCircuitOpen is returned by CircuitBreakerMiddleware while API calls are suspended
+var ErrCircuitOpen = errors.New("tbot: circuit breaker is open")
+
+type circuitBreaker struct {
+	mu               sync.Mutex
+	failureThreshold int
+	resetTimeout     time.Duration
+	failures         int
+	openedAt         time.Time
+	trial            bool
+}
+
+// CircuitBreakerMiddleware suspends API calls after failureThreshold consecutive failures.
+// Only network errors, timeouts, 5xx and 429 replies are failures, other errors like
+// "bot was blocked by the user" don't affect the breaker, neither do calls cancelled by the caller.
+// While open, calls fail with ErrCircuitOpen without sending requests.
+// After resetTimeout one trial call is allowed, its success closes the circuit,
+// its failure opens the circuit for another resetTimeout.
+func CircuitBreakerMiddleware(failureThreshold int, resetTimeout time.Duration) ClientMiddleware {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+	cb := &circuitBreaker{failureThreshold: failureThreshold, resetTimeout: resetTimeout}
+	return func(next APIHandler) APIHandler {
+		return func(ctx context.Context, method string, request url.Values) (json.RawMessage, error) {
+			allowed, trial := cb.allow()
+			if !allowed {
+				return nil, ErrCircuitOpen
+			}
+			result, err := next(ctx, method, request)
+			if err != nil && ctx.Err() == context.Canceled {
+				cb.cancel(trial)
+				return result, err
+			}
+			cb.done(trial, err != nil && isServiceFailure(err))
+			return result, err
+		}
+	}
+}
+
+// allow reports whether the call may be sent and whether it is the trial call
+func (cb *circuitBreaker) allow() (allowed, trial bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.failures < cb.failureThreshold {
+		return true, false
+	}
+	if cb.trial || time.Since(cb.openedAt) < cb.resetTimeout {
+		return false, false
+	}
+	cb.trial = true
+	return true, true
+}
+
+// cancel releases the trial slot of the call cancelled by the caller, the circuit stays open
+func (cb *circuitBreaker) cancel(trial bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if trial {
+		cb.trial = false
+	}
+}
+
+func (cb *circuitBreaker) done(trial, failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if trial {
+		cb.trial = false
+	}
+	if !failed {
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.failures >= cb.failureThreshold {
+		cb.openedAt = time.Now()
+	}
+}
+
+// isServiceFailure reports whether err means Telegram is unavailable or overloaded
+func isServiceFailure(err error) bool {
+	apiErr, ok := err.(*apiError)
+	if !ok {
+		return true
+	}
+	return apiErr.code >= http.StatusInternalServerError || apiErr.code == http.StatusTooManyRequests
+}
+
+// RetryStrategy decides if failed API call should be retried.
+// attempt is the number of calls made so far, starting from 1.
+type RetryStrategy interface {
+	ShouldRetry(attempt int, err error) (bool, time.Duration)
+}
+
+// RetryMiddleware retries API calls failed with network errors, 5xx or 429 replies according to strategy,
+// other errors are returned immediately. Delay requested by Telegram in retry_after
+// overrides the delay of the strategy.
+// Retrying stops as soon as request context is done.
+// Uploads of files read from io.Reader are not retried since the reader is already consumed.
+func RetryMiddleware(s RetryStrategy) ClientMiddleware {
+	return func(next APIHandler) APIHandler {
+		return func(ctx context.Context, method string, request url.Values) (json.RawMessage, error) {
+			for attempt := 1; ; attempt++ {
+				result, err := next(ctx, method, request)
+				if err == nil || ctx.Err() != nil || hasReaderFiles(ctx) || !isServiceFailure(err) {
+					return result, err
+				}
+				retry, delay := s.ShouldRetry(attempt, err)
+				if !retry {
+					return result, err
+				}
+				if apiErr, ok := err.(*apiError); ok && apiErr.retryAfter > 0 {
+					delay = apiErr.retryAfter
+				}
+				timer := time.NewTimer(delay)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					return nil, ctx.Err()
+				}
+			}
+		}
+	}
+}
+
+type constantRetry struct {
+	maxAttempts int
+	delay       time.Duration
+}
+
+// ConstantRetry makes up to maxAttempts calls with fixed delay between them
+func ConstantRetry(maxAttempts int, delay time.Duration) RetryStrategy {
+	return constantRetry{maxAttempts: maxAttempts, delay: delay}
+}
+
+func (r constantRetry) ShouldRetry(attempt int, err error) (bool, time.Duration) {
+	return attempt < r.maxAttempts, r.delay
+}
+
+type exponentialBackoffRetry struct {
+	maxAttempts int
+	base        time.Duration
+	maxDelay    time.Duration
+}
+
+// ExponentialBackoffRetry makes up to maxAttempts calls,
+// doubling delay from base after each failure up to maxDelay
+func ExponentialBackoffRetry(maxAttempts int, base, maxDelay time.Duration) RetryStrategy {
+	return exponentialBackoffRetry{maxAttempts: maxAttempts, base: base, maxDelay: maxDelay}
+}
+
+func (r exponentialBackoffRetry) ShouldRetry(attempt int, err error) (bool, time.Duration) {
+	delay := r.base
+	for i := 1; i < attempt && delay < r.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > r.maxDelay {
+		delay = r.maxDelay
+	}
+	return attempt < r.maxAttempts, delay
+}
@@ -0,0 +1,265 @@
+package tbot_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestCircuitBreakerMiddleware(t *testing.T) {
+	calls := 0
+	fail := true
+	handler := tbot.CircuitBreakerMiddleware(2, 50*time.Millisecond)(
+		func(ctx context.Context, method string, request url.Values) (json.RawMessage, error) {
+			calls++
+			if fail {
+				return nil, errors.New("Internal Server Error")
+			}
+			return json.RawMessage(`true`), nil
+		})
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		handler(ctx, "sendMessage", nil)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls before circuit opens, got %d", calls)
+	}
+	if _, err := handler(ctx, "sendMessage", nil); err != tbot.ErrCircuitOpen {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	fail = false
+	if _, err := handler(ctx, "sendMessage", nil); err != nil {
+		t.Fatalf("trial call failed: %v", err)
+	}
+	if _, err := handler(ctx, "sendMessage", nil); err != nil {
+		t.Fatalf("circuit is not closed after successful trial: %v", err)
+	}
+}
+
+func TestCircuitBreakerIgnoresUserErrors(t *testing.T) {
+	var mu sync.Mutex
+	status := http.StatusBadRequest
+	calls := 0
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"ok":false,"error_code":%d,"description":"%s"}`, status, http.StatusText(status))
+	}
+	c := testClientWithHandler(t, handler, tbot.WithClientMiddleware(tbot.CircuitBreakerMiddleware(2, time.Minute)))
+	for i := 0; i < 3; i++ {
+		if _, err := c.SendMessage("1", "hi"); err == tbot.ErrCircuitOpen {
+			t.Fatalf("circuit is opened by user level error")
+		}
+	}
+	mu.Lock()
+	status = http.StatusBadGateway
+	mu.Unlock()
+	for i := 0; i < 3; i++ {
+		c.SendMessage("1", "hi")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 5 {
+		t.Fatalf("expected circuit to open after 2 server errors, got %d calls", calls)
+	}
+}
+
+func TestCircuitBreakerCountsTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}
+	c := testClientWithHandler(t, handler,
+		tbot.WithDefaultTimeout(10*time.Millisecond),
+		tbot.WithClientMiddleware(tbot.CircuitBreakerMiddleware(1, 20*time.Millisecond)))
+	t.Cleanup(func() { close(release) })
+	if _, err := c.SendMessage("1", "hi"); err == nil || err == tbot.ErrCircuitOpen {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if _, err := c.SendMessage("1", "hi"); err != tbot.ErrCircuitOpen {
+		t.Fatalf("expected timed out call to open the circuit, got %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, err := c.SendMessage("1", "hi"); err == nil || err == tbot.ErrCircuitOpen {
+		t.Fatalf("expected trial call to time out, got %v", err)
+	}
+	if _, err := c.SendMessage("1", "hi"); err != tbot.ErrCircuitOpen {
+		t.Fatalf("expected circuit to stay open after timed out trial, got %v", err)
+	}
+}
+
+func TestCircuitBreakerIgnoresCancelledCalls(t *testing.T) {
+	calls := 0
+	handler := tbot.CircuitBreakerMiddleware(1, time.Minute)(
+		func(ctx context.Context, method string, request url.Values) (json.RawMessage, error) {
+			calls++
+			return nil, ctx.Err()
+		})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	handler(ctx, "sendMessage", nil)
+	if _, err := handler(ctx, "sendMessage", nil); err == tbot.ErrCircuitOpen {
+		t.Fatalf("circuit is opened by cancelled call")
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestCircuitBreakerSingleTrial(t *testing.T) {
+	releaseSlow := make(chan struct{})
+	releaseTrial := make(chan struct{})
+	started := make(chan struct{})
+	handler := tbot.CircuitBreakerMiddleware(1, 10*time.Millisecond)(
+		func(ctx context.Context, method string, request url.Values) (json.RawMessage, error) {
+			switch method {
+			case "slow":
+				started <- struct{}{}
+				<-releaseSlow
+			case "trial":
+				started <- struct{}{}
+				<-releaseTrial
+			}
+			return nil, errors.New("Internal Server Error")
+		})
+	ctx := context.Background()
+	slowDone := make(chan struct{})
+	go func() {
+		handler(ctx, "slow", nil)
+		close(slowDone)
+	}()
+	<-started
+	handler(ctx, "fail", nil)
+	time.Sleep(20 * time.Millisecond)
+	trialDone := make(chan struct{})
+	go func() {
+		handler(ctx, "trial", nil)
+		close(trialDone)
+	}()
+	<-started
+	close(releaseSlow)
+	<-slowDone
+	time.Sleep(20 * time.Millisecond)
+	if _, err := handler(ctx, "other", nil); err != tbot.ErrCircuitOpen {
+		t.Errorf("second trial call is allowed while the first one is running: %v", err)
+	}
+	close(releaseTrial)
+	<-trialDone
+}
+
+func TestClientMiddlewareUpload(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("unable to parse upload: %v", err)
+		}
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, `{"ok":false,"error_code":502,"description":"Bad Gateway"}`)
+	}
+	var methods []string
+	logging := func(next tbot.APIHandler) tbot.APIHandler {
+		return func(ctx context.Context, method string, request url.Values) (json.RawMessage, error) {
+			methods = append(methods, method)
+			return next(ctx, method, request)
+		}
+	}
+	c := testClientWithHandler(t, handler,
+		tbot.WithClientMiddleware(logging, tbot.CircuitBreakerMiddleware(1, time.Minute)))
+	if _, err := c.SendDocumentReader("1", strings.NewReader("data")); err == nil || err == tbot.ErrCircuitOpen {
+		t.Fatalf("expected upload to fail, got %v", err)
+	}
+	if _, err := c.SendDocumentReader("1", strings.NewReader("data")); err != tbot.ErrCircuitOpen {
+		t.Fatalf("expected failed upload to open the circuit, got %v", err)
+	}
+	if len(methods) != 2 || methods[0] != "sendDocument" {
+		t.Errorf("uploads don't pass through middlewares: %v", methods)
+	}
+}
+
+func TestRetryMiddleware(t *testing.T) {
+	calls := 0
+	failing := func(ctx context.Context, method string, request url.Values) (json.RawMessage, error) {
+		calls++
+		if calls < 3 {
+			return nil, errors.New("Too Many Requests")
+		}
+		return json.RawMessage(`true`), nil
+	}
+	handler := tbot.RetryMiddleware(tbot.ConstantRetry(3, time.Millisecond))(failing)
+	if _, err := handler(context.Background(), "sendMessage", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+
+	calls = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	handler = tbot.RetryMiddleware(tbot.ConstantRetry(3, time.Hour))(failing)
+	if _, err := handler(ctx, "sendMessage", nil); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected retrying to stop, got %d calls", calls)
+	}
+}
+
+func TestRetryMiddlewareServiceFailures(t *testing.T) {
+	var mu sync.Mutex
+	var calls []time.Time
+	replies := []string{
+		`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`,
+		`{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`,
+	}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, time.Now())
+		reply := replies[0]
+		if len(replies) > 1 {
+			replies = replies[1:]
+		}
+		fmt.Fprint(w, reply)
+	}
+	c := testClientWithHandler(t, handler,
+		tbot.WithDefaultTimeout(5*time.Second),
+		tbot.WithClientMiddleware(tbot.RetryMiddleware(tbot.ConstantRetry(5, time.Hour))))
+	_, err := c.SendMessage("1", "hi")
+	if err == nil || !strings.Contains(err.Error(), "chat not found") {
+		t.Fatalf("expected chat not found error, got %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(calls) != 2 {
+		t.Fatalf("expected client error not to be retried, got %d calls", len(calls))
+	}
+	if d := calls[1].Sub(calls[0]); d < time.Second {
+		t.Errorf("expected retry after 1s, got %s", d)
+	}
+}
+
+func TestExponentialBackoffRetry(t *testing.T) {
+	s := tbot.ExponentialBackoffRetry(5, time.Second, 5*time.Second)
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for i, d := range expected {
+		retry, delay := s.ShouldRetry(i+1, nil)
+		if !retry || delay != d {
+			t.Errorf("attempt %d: expected retry after %s, got %v %s", i+1, d, retry, delay)
+		}
+	}
+	if retry, _ := s.ShouldRetry(5, nil); retry {
+		t.Errorf("expected no retry after max attempts")
+	}
+}
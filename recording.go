@@ -0,0 +1,109 @@
+package tbot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Interaction is a single recorded API call
+type Interaction struct {
+	Method   string          `json:"method"`
+	Params   url.Values      `json:"params"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// RecordingClient records API calls of wrapped Client for later replay with ReplayClient.
+// File uploads are recorded without file contents, file downloads are not recorded.
+type RecordingClient struct {
+	*Client
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewRecordingClient returns copy of c recording API calls, c itself is not changed
+func NewRecordingClient(c *Client) *RecordingClient {
+	rc := &RecordingClient{Client: c.clone()}
+	next := c.handler
+	rc.Client.handler = func(ctx context.Context, method string, request url.Values) (json.RawMessage, error) {
+		result, err := next(ctx, method, request)
+		in := Interaction{Method: method, Params: request, Response: result}
+		if err != nil {
+			in.Error = err.Error()
+		}
+		rc.mu.Lock()
+		rc.interactions = append(rc.interactions, in)
+		rc.mu.Unlock()
+		return result, err
+	}
+	return rc
+}
+
+// FlushToFile writes recorded interactions to JSON file at path
+func (rc *RecordingClient) FlushToFile(path string) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	data, err := json.MarshalIndent(rc.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// ReplayClient returns responses recorded by RecordingClient without network access.
+// Calls must be made in the same order they were recorded.
+// File downloads fail with an error as they are not recorded.
+type ReplayClient struct {
+	*Client
+	mu           sync.Mutex
+	interactions []Interaction
+}
+
+// NewReplayClient loads interactions written by RecordingClient.FlushToFile
+func NewReplayClient(path string) (*ReplayClient, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rc := &ReplayClient{Client: NewClient("", &http.Client{Transport: notRecordedTransport{}}, "")}
+	err = json.Unmarshal(data, &rc.interactions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode recording: %v", err)
+	}
+	rc.Client.handler = rc.replay
+	return rc, nil
+}
+
+// notRecordedTransport fails requests bypassing client middlewares,
+// like file downloads, since they are never recorded
+type notRecordedTransport struct{}
+
+func (notRecordedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	return nil, fmt.Errorf("replay: request %s is not recorded", req.URL.Path)
+}
+
+func (rc *ReplayClient) replay(ctx context.Context, method string, request url.Values) (json.RawMessage, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if len(rc.interactions) == 0 {
+		return nil, fmt.Errorf("replay: unexpected call %s, no interactions left", method)
+	}
+	in := rc.interactions[0]
+	if in.Method != method {
+		return nil, fmt.Errorf("replay: expected call %s, got %s", in.Method, method)
+	}
+	rc.interactions = rc.interactions[1:]
+	if in.Error != "" {
+		return nil, errors.New(in.Error)
+	}
+	return in.Response, nil
+}
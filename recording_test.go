@@ -0,0 +1,93 @@
+package tbot_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tbot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "golden.json")
+
+	rc := tbot.NewRecordingClient(testClient(t, `{"ok":true,"result":{"message_id":42,"text":"hi"}}`))
+	if _, err := rc.SendMessage("1", "hi"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := rc.FlushToFile(path); err != nil {
+		t.Fatalf("unable to flush: %v", err)
+	}
+
+	replay, err := tbot.NewReplayClient(path)
+	if err != nil {
+		t.Fatalf("unable to load recording: %v", err)
+	}
+	msg, err := replay.SendMessage("1", "hi")
+	if err != nil {
+		t.Fatalf("unexpected replay error: %v", err)
+	}
+	if msg.MessageID != 42 || msg.Text != "hi" {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+	if _, err := replay.SendMessage("1", "hi"); err == nil {
+		t.Errorf("expected error when recording is exhausted")
+	}
+}
+
+func TestRecordingClientCopy(t *testing.T) {
+	c := testClient(t, `{"ok":true,"result":{"message_id":42}}`)
+	rc := tbot.NewRecordingClient(c)
+	if _, err := c.SendMessage("1", "not recorded"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := rc.SendMessage("1", "recorded"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	path := filepath.Join(os.TempDir(), "tbot_recording_copy.json")
+	defer os.Remove(path)
+	if err := rc.FlushToFile(path); err != nil {
+		t.Fatalf("unable to flush: %v", err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read recording: %v", err)
+	}
+	if strings.Contains(string(data), "not recorded") || !strings.Contains(string(data), "recorded") {
+		t.Errorf("unexpected recording: %s", data)
+	}
+}
+
+func TestReplayClientUpload(t *testing.T) {
+	path := filepath.Join(os.TempDir(), "tbot_replay_upload.json")
+	defer os.Remove(path)
+	rc := tbot.NewRecordingClient(testClient(t, `{"ok":true,"result":{"message_id":42}}`))
+	if _, err := rc.SendDocumentReader("1", strings.NewReader("data")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := rc.FlushToFile(path); err != nil {
+		t.Fatalf("unable to flush: %v", err)
+	}
+	replay, err := tbot.NewReplayClient(path)
+	if err != nil {
+		t.Fatalf("unable to load recording: %v", err)
+	}
+	msg, err := replay.SendDocumentReader("1", strings.NewReader("data"))
+	if err != nil {
+		t.Fatalf("unexpected replay error: %v", err)
+	}
+	if msg.MessageID != 42 {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+	_, err = replay.DownloadFile(&tbot.File{FilePath: "photos/1.jpg"})
+	if err == nil || !strings.Contains(err.Error(), "not recorded") {
+		t.Errorf("expected not recorded error, got %v", err)
+	}
+}
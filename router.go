@@ -0,0 +1,186 @@
+package tbot
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// updateRouter dispatches updates to handlers registered for their types
+type updateRouter struct {
+	messageHandler            func(*Message)
+	editedMessageHandler      func(*Message)
+	channelPostHandler        func(*Message)
+	editedChannelPostHandler  func(*Message)
+	inlineQueryHandler        func(*InlineQuery)
+	chosenInlineResultHandler func(*ChosenInlineResult)
+	callbackQueryHandler      func(*CallbackQuery)
+	shippingQueryHandler      func(*ShippingQuery)
+	preCheckoutQueryHandler   func(*PreCheckoutQuery)
+	pollHandler               func(*Poll)
+	pollAnswerHandler         func(*PollAnswer)
+	myChatMemberHandler       func(*ChatMemberUpdated)
+	chatMemberHandler         func(*ChatMemberUpdated)
+	chatJoinRequestHandler    func(*ChatJoinRequest)
+	messageReactionHandler    func(*MessageReactionUpdated)
+}
+
+// OnMessage sets handler for new incoming messages
+func (r *updateRouter) OnMessage(fn func(*Message)) {
+	r.messageHandler = fn
+}
+
+// OnEditedMessage sets handler for edited messages
+func (r *updateRouter) OnEditedMessage(fn func(*Message)) {
+	r.editedMessageHandler = fn
+}
+
+// OnChannelPost sets handler for new channel posts
+func (r *updateRouter) OnChannelPost(fn func(*Message)) {
+	r.channelPostHandler = fn
+}
+
+// OnEditedChannelPost sets handler for edited channel posts
+func (r *updateRouter) OnEditedChannelPost(fn func(*Message)) {
+	r.editedChannelPostHandler = fn
+}
+
+// OnInlineQuery sets handler for inline queries
+func (r *updateRouter) OnInlineQuery(fn func(*InlineQuery)) {
+	r.inlineQueryHandler = fn
+}
+
+// OnChosenInlineResult sets handler for chosen inline results
+func (r *updateRouter) OnChosenInlineResult(fn func(*ChosenInlineResult)) {
+	r.chosenInlineResultHandler = fn
+}
+
+// OnCallbackQuery sets handler for callback queries
+func (r *updateRouter) OnCallbackQuery(fn func(*CallbackQuery)) {
+	r.callbackQueryHandler = fn
+}
+
+// OnShippingQuery sets handler for shipping queries
+func (r *updateRouter) OnShippingQuery(fn func(*ShippingQuery)) {
+	r.shippingQueryHandler = fn
+}
+
+// OnPreCheckoutQuery sets handler for pre-checkout queries
+func (r *updateRouter) OnPreCheckoutQuery(fn func(*PreCheckoutQuery)) {
+	r.preCheckoutQueryHandler = fn
+}
+
+// OnPoll sets handler for poll state updates
+func (r *updateRouter) OnPoll(fn func(*Poll)) {
+	r.pollHandler = fn
+}
+
+// OnPollAnswer sets handler for poll answers
+func (r *updateRouter) OnPollAnswer(fn func(*PollAnswer)) {
+	r.pollAnswerHandler = fn
+}
+
+// OnMyChatMember sets handler for bot's chat member status updates
+func (r *updateRouter) OnMyChatMember(fn func(*ChatMemberUpdated)) {
+	r.myChatMemberHandler = fn
+}
+
+// OnChatMember sets handler for chat member status updates
+func (r *updateRouter) OnChatMember(fn func(*ChatMemberUpdated)) {
+	r.chatMemberHandler = fn
+}
+
+// OnChatJoinRequest sets handler for chat join requests
+func (r *updateRouter) OnChatJoinRequest(fn func(*ChatJoinRequest)) {
+	r.chatJoinRequestHandler = fn
+}
+
+// OnMessageReaction sets handler for message reaction changes
+func (r *updateRouter) OnMessageReaction(fn func(*MessageReactionUpdated)) {
+	r.messageReactionHandler = fn
+}
+
+func (r *updateRouter) dispatch(up *Update) {
+	switch {
+	case up.Message != nil && r.messageHandler != nil:
+		r.messageHandler(up.Message)
+	case up.EditedMessage != nil && r.editedMessageHandler != nil:
+		r.editedMessageHandler(up.EditedMessage)
+	case up.ChannelPost != nil && r.channelPostHandler != nil:
+		r.channelPostHandler(up.ChannelPost)
+	case up.EditedChannelPost != nil && r.editedChannelPostHandler != nil:
+		r.editedChannelPostHandler(up.EditedChannelPost)
+	case up.InlineQuery != nil && r.inlineQueryHandler != nil:
+		r.inlineQueryHandler(up.InlineQuery)
+	case up.ChosenInlineResult != nil && r.chosenInlineResultHandler != nil:
+		r.chosenInlineResultHandler(up.ChosenInlineResult)
+	case up.CallbackQuery != nil && r.callbackQueryHandler != nil:
+		r.callbackQueryHandler(up.CallbackQuery)
+	case up.ShippingQuery != nil && r.shippingQueryHandler != nil:
+		r.shippingQueryHandler(up.ShippingQuery)
+	case up.PreCheckoutQuery != nil && r.preCheckoutQueryHandler != nil:
+		r.preCheckoutQueryHandler(up.PreCheckoutQuery)
+	case up.Poll != nil && r.pollHandler != nil:
+		r.pollHandler(up.Poll)
+	case up.PollAnswer != nil && r.pollAnswerHandler != nil:
+		r.pollAnswerHandler(up.PollAnswer)
+	case up.MyChatMember != nil && r.myChatMemberHandler != nil:
+		r.myChatMemberHandler(up.MyChatMember)
+	case up.ChatMember != nil && r.chatMemberHandler != nil:
+		r.chatMemberHandler(up.ChatMember)
+	case up.ChatJoinRequest != nil && r.chatJoinRequestHandler != nil:
+		r.chatJoinRequestHandler(up.ChatJoinRequest)
+	case up.MessageReaction != nil && r.messageReactionHandler != nil:
+		r.messageReactionHandler(up.MessageReaction)
+	}
+}
+
+// PollingRouter receives updates with long polling and dispatches them to handlers
+type PollingRouter struct {
+	updateRouter
+	client *Client
+}
+
+// NewPollingRouter creates new PollingRouter for the client
+func NewPollingRouter(client *Client) *PollingRouter {
+	return &PollingRouter{client: client}
+}
+
+// Start removes webhook and polls updates until ctx is done.
+// Updates are handled one by one in the order they arrive,
+// a slow handler delays the following updates.
+func (r *PollingRouter) Start(ctx context.Context) error {
+	err := r.client.deleteWebhook()
+	if err != nil {
+		return err
+	}
+	r.client.startPolling(ctx, url.Values{}, r.dispatch, func(err error) {
+		r.client.logger.Errorf("unable to get updates: %v", err)
+	})
+	return nil
+}
+
+// WebhookRouter receives updates via webhook and dispatches them to handlers.
+// It has the same handler registration methods as PollingRouter.
+type WebhookRouter struct {
+	updateRouter
+	client *Client
+}
+
+// NewWebhookRouter creates new WebhookRouter for the client
+func NewWebhookRouter(client *Client) *WebhookRouter {
+	return &WebhookRouter{client: client}
+}
+
+// Handler returns http.Handler accepting webhook requests.
+// Update is handled before the request is answered, so the number of
+// concurrent handlers is bounded by connections Telegram opens to the webhook.
+func (r *WebhookRouter) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		up, ok := readWebhookUpdate(w, req, r.client.webhookSecret, r.client.logger)
+		if !ok {
+			return
+		}
+		r.dispatch(up)
+	})
+}
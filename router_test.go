@@ -0,0 +1,61 @@
+package tbot_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestPollingRouter(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/deleteWebhook") {
+			fmt.Fprint(w, `{"ok": true, "result": true}`)
+			return
+		}
+		fmt.Fprint(w, `{"ok": true, "result": [
+			{"update_id": 1, "message": {"text": "first", "chat": {"id": 1}}},
+			{"update_id": 2, "message": {"text": "second", "chat": {"id": 1}}}
+		]}`)
+	}
+	c := testClientWithHandler(t, handler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var texts []string
+	r := tbot.NewPollingRouter(c)
+	r.OnMessage(func(m *tbot.Message) {
+		texts = append(texts, m.Text)
+		if len(texts) == 2 {
+			cancel()
+		}
+	})
+	err := r.Start(ctx)
+	if err != nil {
+		t.Fatalf("error on start: %v", err)
+	}
+	if len(texts) < 2 || texts[0] != "first" || texts[1] != "second" {
+		t.Fatalf("updates are not handled in order: %v", texts)
+	}
+}
+
+func TestWebhookRouter(t *testing.T) {
+	r := tbot.NewWebhookRouter(tbot.NewClient(token, nil, "https://example.com"))
+	received := make(chan *tbot.CallbackQuery, 1)
+	r.OnCallbackQuery(func(cq *tbot.CallbackQuery) {
+		received <- cq
+	})
+	rec := httptest.NewRecorder()
+	body := strings.NewReader(`{"update_id": 1, "callback_query": {"id": "cq", "data": "yes"}}`)
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", body))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", rec.Code)
+	}
+	if cq := <-received; cq.Data != "yes" {
+		t.Fatalf("unexpected callback query: %+v", cq)
+	}
+}
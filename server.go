@@ -21,6 +21,7 @@ type Server struct {
 	httpClient    *http.Client
 	client        *Client
 	token         string
+	clientOptions []ClientOption
 	logger        Logger
 	stop          chan struct{}
 	updatesParams url.Values
@@ -61,6 +62,7 @@ type messageHandler struct {
 New creates new Server. Available options:
 	WithWebook(url, addr string)
 	WithHTTPClient(client *http.Client)
+	WithClientOptions(options ...ClientOption)
 */
 func New(token string, options ...ServerOption) *Server {
 	s := &Server{
@@ -84,7 +86,7 @@ func New(token string, options ...ServerOption) *Server {
 		opt(s)
 	}
 	// bot, err :=  tgbotapi.NewBotAPIWithClient(token, s.httpClient)
-	s.client = NewClient(token, s.httpClient, apiBaseURL)
+	s.client = NewClient(token, s.httpClient, apiBaseURL, s.clientOptions...)
 	return s
 }
 
@@ -104,6 +106,14 @@ func WithHTTPClient(client *http.Client) ServerOption {
 	}
 }
 
+// WithClientOptions sets options for the underlying Client.
+// e.g. WithClientOptions(WithWebhookSecret("secret"))
+func WithClientOptions(options ...ClientOption) ServerOption {
+	return func(s *Server) {
+		s.clientOptions = append(s.clientOptions, options...)
+	}
+}
+
 // WithLogger sets logger for tbot
 func WithLogger(logger Logger) ServerOption {
 	return func(s *Server) {
@@ -188,10 +198,8 @@ func (s *Server) listenUpdates() (chan *Update, error) {
 	}
 	updates := make(chan *Update)
 	handler := func(w http.ResponseWriter, r *http.Request) {
-		up := &Update{}
-		err := json.NewDecoder(r.Body).Decode(up)
-		if err != nil {
-			s.logger.Errorf("unable to decode update: %v", err)
+		up, ok := readWebhookUpdate(w, r, s.client.webhookSecret, s.logger)
+		if !ok {
 			return
 		}
 		updates <- up
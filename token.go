@@ -0,0 +1,48 @@
+package tbot
+
+import (
+	"context"
+	"fmt"
+)
+
+// TokenRefresher provides actual bot token, for example read from a secrets storage.
+// It is called before each API request, so it should cache the token if fetching is expensive.
+type TokenRefresher interface {
+	GetToken(ctx context.Context) (string, error)
+}
+
+// WithTokenRefresher sets TokenRefresher used to rotate bot token without restart
+func WithTokenRefresher(r TokenRefresher) ClientOption {
+	return func(c *Client) {
+		c.tokenRefresher = r
+	}
+}
+
+// endpoint returns URL of the API method, refreshing the token if TokenRefresher is set
+func (c *Client) endpoint(ctx context.Context, method string) (string, error) {
+	if c.tokenRefresher != nil {
+		token, err := c.tokenRefresher.GetToken(ctx)
+		if err != nil {
+			return "", fmt.Errorf("unable to get token: %v", err)
+		}
+		c.setToken(token)
+	}
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return fmt.Sprintf(c.url, method), nil
+}
+
+func (c *Client) setToken(token string) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	if token != c.token {
+		c.token = token
+		c.url = fmt.Sprintf("%s/bot%s/", c.baseURL, token) + "%s"
+	}
+}
+
+func (c *Client) currentToken() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.token
+}
@@ -0,0 +1,41 @@
+package tbot_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+type rotatingToken struct {
+	tokens []string
+}
+
+func (r *rotatingToken) GetToken(ctx context.Context) (string, error) {
+	token := r.tokens[0]
+	if len(r.tokens) > 1 {
+		r.tokens = r.tokens[1:]
+	}
+	return token, nil
+}
+
+func TestTokenRefresher(t *testing.T) {
+	var paths []string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		fmt.Fprint(w, `{"ok":true,"result":true}`)
+	}
+	refresher := &rotatingToken{tokens: []string{"first", "second"}}
+	c := testClientWithHandler(t, handler, tbot.WithTokenRefresher(refresher))
+	for i := 0; i < 2; i++ {
+		err := c.DeleteChatPhoto("1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(paths) != 2 || paths[0] != "/botfirst/deleteChatPhoto" || paths[1] != "/botsecond/deleteChatPhoto" {
+		t.Fatalf("unexpected request paths: %v", paths)
+	}
+}
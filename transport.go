@@ -0,0 +1,85 @@
+package tbot
+
+import (
+	"net/http"
+	"time"
+)
+
+type transportOptions struct {
+	http2           bool
+	maxIdleConns    int
+	maxConnsPerHost int
+	idleConnTimeout time.Duration
+}
+
+// WithHTTP2 makes client try HTTP/2 when connecting to Telegram.
+// Default transport tries HTTP/2 already, the option matters for custom *http.Transport
+// with its own TLS config or dialer, on which Go doesn't enable HTTP/2 automatically.
+func WithHTTP2() ClientOption {
+	return func(c *Client) {
+		c.transportOptions().http2 = true
+	}
+}
+
+// WithMaxIdleConns sets maximum number of idle keep-alive connections.
+// All requests go to the same host, so per host limit is raised as well.
+func WithMaxIdleConns(n int) ClientOption {
+	return func(c *Client) {
+		c.transportOptions().maxIdleConns = n
+	}
+}
+
+// WithMaxConnsPerHost limits total number of connections to Telegram
+func WithMaxConnsPerHost(n int) ClientOption {
+	return func(c *Client) {
+		c.transportOptions().maxConnsPerHost = n
+	}
+}
+
+// WithIdleConnTimeout sets how long idle connection is kept open
+func WithIdleConnTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.transportOptions().idleConnTimeout = d
+	}
+}
+
+func (c *Client) transportOptions() *transportOptions {
+	if c.transport == nil {
+		c.transport = &transportOptions{}
+	}
+	return c.transport
+}
+
+// apply returns copy of httpClient with configured transport.
+// Given client and its transport are left untouched.
+// Custom transports other than *http.Transport can't be configured and are kept as is.
+func (o *transportOptions) apply(httpClient *http.Client) *http.Client {
+	hc := &http.Client{}
+	if httpClient != nil {
+		*hc = *httpClient
+	}
+	var tr *http.Transport
+	switch t := hc.Transport.(type) {
+	case nil:
+		tr = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		tr = t.Clone()
+	default:
+		return hc
+	}
+	if o.http2 {
+		tr.ForceAttemptHTTP2 = true
+	}
+	if o.maxIdleConns > 0 {
+		tr.MaxIdleConns = o.maxIdleConns
+		tr.MaxIdleConnsPerHost = o.maxIdleConns
+	}
+	if o.maxConnsPerHost > 0 {
+		tr.MaxConnsPerHost = o.maxConnsPerHost
+	}
+	if o.idleConnTimeout > 0 {
+		tr.IdleConnTimeout = o.idleConnTimeout
+	}
+	hc.Transport = tr
+	return hc
+}
@@ -0,0 +1,53 @@
+package tbot_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestTransportOptions(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok":true,"result":{"id":1}}`)
+	}
+	c := testClientWithHandler(t, handler,
+		tbot.WithHTTP2(),
+		tbot.WithMaxIdleConns(100),
+		tbot.WithMaxConnsPerHost(10),
+		tbot.WithIdleConnTimeout(time.Minute),
+	)
+	if _, err := c.GetMe(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tr, ok := tbot.HTTPTransport(c).(*http.Transport)
+	if !ok {
+		t.Fatalf("unexpected transport: %T", tbot.HTTPTransport(c))
+	}
+	if !tr.ForceAttemptHTTP2 {
+		t.Errorf("HTTP/2 is not enabled")
+	}
+	if tr.MaxIdleConns != 100 || tr.MaxIdleConnsPerHost != 100 {
+		t.Errorf("unexpected idle connections limits: %d, %d", tr.MaxIdleConns, tr.MaxIdleConnsPerHost)
+	}
+	if tr.MaxConnsPerHost != 10 {
+		t.Errorf("unexpected connections per host limit: %d", tr.MaxConnsPerHost)
+	}
+	if tr.IdleConnTimeout != time.Minute {
+		t.Errorf("unexpected idle connection timeout: %s", tr.IdleConnTimeout)
+	}
+}
+
+func TestTransportOptionsKeepHTTPClient(t *testing.T) {
+	transport := &http.Transport{}
+	httpClient := &http.Client{Transport: transport}
+	c := tbot.NewClient(token, httpClient, "https://example.com", tbot.WithMaxIdleConns(100))
+	if transport.MaxIdleConns != 0 || httpClient.Transport != transport {
+		t.Errorf("given http client was modified")
+	}
+	if tbot.HTTPTransport(c) == transport {
+		t.Errorf("given transport is used without options")
+	}
+}
@@ -17,14 +17,27 @@ type User struct {
 
 // ChatPhoto represents a chat photo
 type ChatPhoto struct {
-	SmallFileID string `json:"small_file_id"`
-	BigFileID   string `json:"big_file_id"`
+	SmallFileID       string `json:"small_file_id"`
+	SmallFileUniqueID string `json:"small_file_unique_id"`
+	BigFileID         string `json:"big_file_id"`
+	BigFileUniqueID   string `json:"big_file_unique_id"`
 }
 
+// ChatType is a type of chat
+type ChatType string
+
+// Chat types
+const (
+	ChatTypePrivate    ChatType = "private"
+	ChatTypeGroup      ChatType = "group"
+	ChatTypeSupergroup ChatType = "supergroup"
+	ChatTypeChannel    ChatType = "channel"
+)
+
 // Chat represents a chat
 type Chat struct {
 	ID                          string
-	Type                        string
+	Type                        ChatType
 	Title                       string
 	Username                    string
 	FirstName                   string
@@ -33,6 +46,9 @@ type Chat struct {
 	Description                 string
 	InviteLink                  string
 	PinnedMessage               *Message
+	Permissions                 *ChatPermissions
+	SlowModeDelay               int
+	MessageAutoDeleteTime       int
 	StickerSetName              string
 	AllMembersAreAdministrators bool
 	CanSetStickerSet            bool
@@ -41,19 +57,22 @@ type Chat struct {
 // UnmarshalJSON implements json.Unmarshaler
 func (c *Chat) UnmarshalJSON(data []byte) error {
 	s := &struct {
-		ID                          int        `json:"id"`
-		Type                        string     `json:"type"`
-		Title                       string     `json:"title"`
-		Username                    string     `json:"username"`
-		FirstName                   string     `json:"first_name"`
-		LastName                    string     `json:"last_name"`
-		Photo                       *ChatPhoto `json:"photo"`
-		Description                 string     `json:"description"`
-		InviteLink                  string     `json:"invite_link"`
-		PinnedMessage               *Message   `json:"pinned_message"`
-		StickerSetName              string     `json:"sticker_set_name"`
-		AllMembersAreAdministrators bool       `json:"all_members_are_administrators"`
-		CanSetStickerSet            bool       `json:"can_set_sticker_set"`
+		ID                          int              `json:"id"`
+		Type                        ChatType         `json:"type"`
+		Title                       string           `json:"title"`
+		Username                    string           `json:"username"`
+		FirstName                   string           `json:"first_name"`
+		LastName                    string           `json:"last_name"`
+		Photo                       *ChatPhoto       `json:"photo"`
+		Description                 string           `json:"description"`
+		InviteLink                  string           `json:"invite_link"`
+		PinnedMessage               *Message         `json:"pinned_message"`
+		Permissions                 *ChatPermissions `json:"permissions"`
+		SlowModeDelay               int              `json:"slow_mode_delay"`
+		MessageAutoDeleteTime       int              `json:"message_auto_delete_time"`
+		StickerSetName              string           `json:"sticker_set_name"`
+		AllMembersAreAdministrators bool             `json:"all_members_are_administrators"`
+		CanSetStickerSet            bool             `json:"can_set_sticker_set"`
 	}{}
 	err := json.Unmarshal(data, s)
 	if err != nil {
@@ -70,6 +89,9 @@ func (c *Chat) UnmarshalJSON(data []byte) error {
 		Description:                 s.Description,
 		InviteLink:                  s.InviteLink,
 		PinnedMessage:               s.PinnedMessage,
+		Permissions:                 s.Permissions,
+		SlowModeDelay:               s.SlowModeDelay,
+		MessageAutoDeleteTime:       s.MessageAutoDeleteTime,
 		StickerSetName:              s.StickerSetName,
 		AllMembersAreAdministrators: s.AllMembersAreAdministrators,
 		CanSetStickerSet:            s.CanSetStickerSet,
@@ -77,6 +99,26 @@ func (c *Chat) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// IsPrivate reports whether chat is a private chat
+func (c *Chat) IsPrivate() bool {
+	return c.Type == ChatTypePrivate
+}
+
+// IsGroup reports whether chat is a group
+func (c *Chat) IsGroup() bool {
+	return c.Type == ChatTypeGroup
+}
+
+// IsSupergroup reports whether chat is a supergroup
+func (c *Chat) IsSupergroup() bool {
+	return c.Type == ChatTypeSupergroup
+}
+
+// IsChannel reports whether chat is a channel
+func (c *Chat) IsChannel() bool {
+	return c.Type == ChatTypeChannel
+}
+
 // MessageEntity represents one special entity in a text message.
 // For example, hashtags, usernames, URLs, etc.
 type MessageEntity struct {
@@ -98,11 +140,14 @@ type Audio struct {
 }
 
 // PhotoSize represents one size of a photo or a file/sticker thumbnail.
+// FileUniqueID is the same over time and for different bots,
+// use it instead of FileID as a cache key.
 type PhotoSize struct {
-	FileID   string `json:"file_id"`
-	Width    int    `json:"width"`
-	Height   int    `json:"height"`
-	FileSize int    `json:"file_size"`
+	FileID       string `json:"file_id"`
+	FileUniqueID string `json:"file_unique_id"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	FileSize     int    `json:"file_size"`
 }
 
 // Document represents a general file
@@ -136,25 +181,46 @@ type Animation struct {
 	FileSize int        `json:"file_size"`
 }
 
+// Sticker types
+const (
+	StickerTypeRegular     = "regular"
+	StickerTypeMask        = "mask"
+	StickerTypeCustomEmoji = "custom_emoji"
+)
+
 // Sticker represents a sticker
 type Sticker struct {
-	FileID       string        `json:"file_id"`
-	Width        int           `json:"width"`
-	Height       int           `json:"height"`
-	Thumb        *PhotoSize    `json:"thumb"`
-	Emoji        string        `json:"emoji"`
-	MaskPosition *MaskPosition `json:"mask_position"`
-	SetName      string        `json:"set_name"`
-	FileSize     int           `json:"file_size"`
+	FileID          string        `json:"file_id"`
+	FileUniqueID    string        `json:"file_unique_id"`
+	Type            string        `json:"type"`
+	Width           int           `json:"width"`
+	Height          int           `json:"height"`
+	IsAnimated      bool          `json:"is_animated"`
+	IsVideo         bool          `json:"is_video"`
+	Thumbnail       *PhotoSize    `json:"thumbnail"`
+	Emoji           string        `json:"emoji"`
+	SetName         string        `json:"set_name"`
+	CustomEmojiID   string        `json:"custom_emoji_id"`
+	NeedsRepainting bool          `json:"needs_repainting"`
+	MaskPosition    *MaskPosition `json:"mask_position"`
+	FileSize        int           `json:"file_size"`
 }
 
+// Mask points for MaskPosition
+const (
+	MaskPointForehead = "forehead"
+	MaskPointEyes     = "eyes"
+	MaskPointMouth    = "mouth"
+	MaskPointChin     = "chin"
+)
+
 // MaskPosition describes the position on faces
 // where a mask should be placed by default
 type MaskPosition struct {
 	Point  string  `json:"point"`
-	XShift float32 `json:"x_shift"`
-	YShift float32 `json:"y_shift"`
-	Scale  float32 `json:"scale"`
+	XShift float64 `json:"x_shift"`
+	YShift float64 `json:"y_shift"`
+	Scale  float64 `json:"scale"`
 }
 
 // Video represents a video file
@@ -207,7 +273,9 @@ type Venue struct {
 	FoursquareID string   `json:"foursquare_id"`
 }
 
-// Invoice contains basic information about an invoice
+// Invoice contains basic information about an invoice.
+// It is received in Message.Invoice and passed to SendInvoice,
+// where TotalAmount is ignored in favor of prices.
 type Invoice struct {
 	Title          string `json:"title"`
 	Description    string `json:"description"`
@@ -247,51 +315,397 @@ type ShippingAddress struct {
 
 // Message represents a message
 type Message struct {
-	MessageID             int                `json:"message_id"`
-	From                  *User              `json:"from"`
-	Date                  int64              `json:"date"`
-	Chat                  Chat               `json:"chat"`
-	ForwardFrom           *User              `json:"forward_from"`
-	ForwardFromChat       *Chat              `json:"forward_from_chat"`
-	ForwardFromMessageID  int                `json:"forward_from_message_id"`
-	ForwardSignature      string             `json:"forward_signature"`
-	ForwardSenderName     string             `json:"forward_sender_name"`
-	ForwardDate           int64              `json:"forward_date"`
-	ReplyToMessage        *Message           `json:"reply_to_message"`
-	EditDate              int64              `json:"edit_date"`
-	MediaGroupID          string             `json:"media_group_id"`
-	AuthorSignature       string             `json:"author_signature"`
-	Text                  string             `json:"text"`
-	Entities              []*MessageEntity   `json:"entities"`
-	CaptionEntities       []*MessageEntity   `json:"caption_entities"`
-	Audio                 *Audio             `json:"audio"`
-	Document              *Document          `json:"document"`
-	Game                  *Game              `json:"game"`
-	Photo                 []*PhotoSize       `json:"photo"`
-	Sticker               *Sticker           `json:"sticker"`
-	Video                 *Video             `json:"video"`
-	Voice                 *Voice             `json:"voice"`
-	VideoNote             *VideoNote         `json:"video_note"`
-	Caption               string             `json:"caption"`
-	Contact               *Contact           `json:"contact"`
-	Location              *Location          `json:"location"`
-	Venue                 *Venue             `json:"venue"`
-	Poll                  *Poll              `json:"poll"`
-	NewChatMembers        []*User            `json:"new_chat_members"`
-	LeftChatMember        *User              `json:"left_chat_member"`
-	NewChatTitle          string             `json:"new_chat_title"`
-	NewChatPhoto          []*PhotoSize       `json:"new_chat_photo"`
-	DeleteChatPhoto       bool               `json:"delete_chat_photo"`
-	GroupChatCreated      bool               `json:"group_chat_created"`
-	SupergroupChatCreated bool               `json:"supergroup_chat_created"`
-	ChannelChatCreated    bool               `json:"channel_chat_created"`
-	MigrateToChatID       int                `json:"migrate_to_chat_id"`
-	MigrateFromChatID     int                `json:"migrate_from_chat_id"`
-	PinnedMessage         *Message           `json:"pinned_message"`
-	Invoice               *Invoice           `json:"invoice"`
-	SuccessfulPayment     *SuccessfulPayment `json:"successful_payment"`
-	ConnectedWebsite      string             `json:"connected_website"`
-	PassportData          *PassportData      `json:"passport_data"`
+	MessageID                     int                            `json:"message_id"`
+	From                          *User                          `json:"from"`
+	Date                          int64                          `json:"date"`
+	Chat                          Chat                           `json:"chat"`
+	ForwardFrom                   *User                          `json:"forward_from"`
+	ForwardFromChat               *Chat                          `json:"forward_from_chat"`
+	ForwardFromMessageID          int                            `json:"forward_from_message_id"`
+	ForwardSignature              string                         `json:"forward_signature"`
+	ForwardSenderName             string                         `json:"forward_sender_name"`
+	ForwardDate                   int64                          `json:"forward_date"`
+	ReplyToMessage                *Message                       `json:"reply_to_message"`
+	EditDate                      int64                          `json:"edit_date"`
+	MediaGroupID                  string                         `json:"media_group_id"`
+	AuthorSignature               string                         `json:"author_signature"`
+	Text                          string                         `json:"text"`
+	Entities                      []*MessageEntity               `json:"entities"`
+	CaptionEntities               []*MessageEntity               `json:"caption_entities"`
+	Audio                         *Audio                         `json:"audio"`
+	Document                      *Document                      `json:"document"`
+	Game                          *Game                          `json:"game"`
+	Photo                         []*PhotoSize                   `json:"photo"`
+	Sticker                       *Sticker                       `json:"sticker"`
+	Video                         *Video                         `json:"video"`
+	Voice                         *Voice                         `json:"voice"`
+	VideoNote                     *VideoNote                     `json:"video_note"`
+	Caption                       string                         `json:"caption"`
+	Contact                       *Contact                       `json:"contact"`
+	Location                      *Location                      `json:"location"`
+	Venue                         *Venue                         `json:"venue"`
+	Poll                          *Poll                          `json:"poll"`
+	NewChatMembers                []*User                        `json:"new_chat_members"`
+	LeftChatMember                *User                          `json:"left_chat_member"`
+	NewChatTitle                  string                         `json:"new_chat_title"`
+	NewChatPhoto                  []*PhotoSize                   `json:"new_chat_photo"`
+	DeleteChatPhoto               bool                           `json:"delete_chat_photo"`
+	GroupChatCreated              bool                           `json:"group_chat_created"`
+	SupergroupChatCreated         bool                           `json:"supergroup_chat_created"`
+	ChannelChatCreated            bool                           `json:"channel_chat_created"`
+	MigrateToChatID               int                            `json:"migrate_to_chat_id"`
+	MigrateFromChatID             int                            `json:"migrate_from_chat_id"`
+	PinnedMessage                 *Message                       `json:"pinned_message"`
+	Invoice                       *Invoice                       `json:"invoice"`
+	SuccessfulPayment             *SuccessfulPayment             `json:"successful_payment"`
+	ConnectedWebsite              string                         `json:"connected_website"`
+	PassportData                  *PassportData                  `json:"passport_data"`
+	ProximityAlertTriggered       *ProximityAlertTriggered       `json:"proximity_alert_triggered"`
+	VideoChatScheduled            *VideoChatScheduled            `json:"video_chat_scheduled"`
+	VideoChatStarted              *VideoChatStarted              `json:"video_chat_started"`
+	VideoChatEnded                *VideoChatEnded                `json:"video_chat_ended"`
+	VideoChatParticipantsInvited  *VideoChatParticipantsInvited  `json:"video_chat_participants_invited"`
+	MessageAutoDeleteTimerChanged *MessageAutoDeleteTimerChanged `json:"message_auto_delete_timer_changed"`
+	ForwardOrigin                 MessageOrigin                  `json:"forward_origin"`
+	ExternalReply                 *ExternalReplyInfo             `json:"external_reply"`
+	Quote                         *TextQuote                     `json:"quote"`
+	WebAppData                    *WebAppData                    `json:"web_app_data"`
+	Giveaway                      *Giveaway                      `json:"giveaway"`
+	GiveawayCreated               *GiveawayCreated               `json:"giveaway_created"`
+	GiveawayWinners               *GiveawayWinners               `json:"giveaway_winners"`
+	GiveawayCompleted             *GiveawayCompleted             `json:"giveaway_completed"`
+	ChatBackgroundSet             *ChatBackground                `json:"chat_background_set"`
+}
+
+// ChatBackground represents a chat background
+type ChatBackground struct {
+	Type BackgroundType `json:"type"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (b *ChatBackground) UnmarshalJSON(data []byte) error {
+	s := &struct {
+		Type json.RawMessage `json:"type"`
+	}{}
+	err := json.Unmarshal(data, s)
+	if err != nil {
+		return err
+	}
+	b.Type, err = unmarshalBackgroundType(s.Type)
+	return err
+}
+
+// BackgroundType describes the type of a background, it can be one of
+// BackgroundTypeFill, BackgroundTypeWallpaper, BackgroundTypePattern or BackgroundTypeChatTheme
+type BackgroundType interface {
+	backgroundType()
+}
+
+var (
+	_ BackgroundType = BackgroundTypeFill{}
+	_ BackgroundType = BackgroundTypeWallpaper{}
+	_ BackgroundType = BackgroundTypePattern{}
+	_ BackgroundType = BackgroundTypeChatTheme{}
+)
+
+// BackgroundFill describes the way a background is filled based on the selected colors.
+// Type is one of "solid", "gradient" or "freeform_gradient", only fields of that type are set.
+type BackgroundFill struct {
+	Type          string `json:"type"`
+	Color         int    `json:"color"`
+	TopColor      int    `json:"top_color"`
+	BottomColor   int    `json:"bottom_color"`
+	RotationAngle int    `json:"rotation_angle"`
+	Colors        []int  `json:"colors"`
+}
+
+// BackgroundTypeFill is a background automatically filled based on the selected colors
+type BackgroundTypeFill struct {
+	Type             string         `json:"type"`
+	Fill             BackgroundFill `json:"fill"`
+	DarkThemeDimming int            `json:"dark_theme_dimming"`
+}
+
+func (BackgroundTypeFill) backgroundType() {}
+
+// BackgroundTypeWallpaper is a background with a wallpaper in the JPEG format
+type BackgroundTypeWallpaper struct {
+	Type             string   `json:"type"`
+	Document         Document `json:"document"`
+	DarkThemeDimming int      `json:"dark_theme_dimming"`
+	IsBlurred        bool     `json:"is_blurred"`
+	IsMoving         bool     `json:"is_moving"`
+}
+
+func (BackgroundTypeWallpaper) backgroundType() {}
+
+// BackgroundTypePattern is a PNG or TGV pattern combined with the background fill chosen by the user
+type BackgroundTypePattern struct {
+	Type       string         `json:"type"`
+	Document   Document       `json:"document"`
+	Fill       BackgroundFill `json:"fill"`
+	Intensity  int            `json:"intensity"`
+	IsInverted bool           `json:"is_inverted"`
+	IsMoving   bool           `json:"is_moving"`
+}
+
+func (BackgroundTypePattern) backgroundType() {}
+
+// BackgroundTypeChatTheme is taken directly from a built-in chat theme
+type BackgroundTypeChatTheme struct {
+	Type      string `json:"type"`
+	ThemeName string `json:"theme_name"`
+}
+
+func (BackgroundTypeChatTheme) backgroundType() {}
+
+func unmarshalBackgroundType(data json.RawMessage) (BackgroundType, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	t := &struct {
+		Type string `json:"type"`
+	}{}
+	err := json.Unmarshal(data, t)
+	if err != nil {
+		return nil, err
+	}
+	switch t.Type {
+	case "fill":
+		b := BackgroundTypeFill{}
+		err = json.Unmarshal(data, &b)
+		return b, err
+	case "wallpaper":
+		b := BackgroundTypeWallpaper{}
+		err = json.Unmarshal(data, &b)
+		return b, err
+	case "pattern":
+		b := BackgroundTypePattern{}
+		err = json.Unmarshal(data, &b)
+		return b, err
+	case "chat_theme":
+		b := BackgroundTypeChatTheme{}
+		err = json.Unmarshal(data, &b)
+		return b, err
+	}
+	return nil, nil
+}
+
+// Giveaway represents a message about a scheduled giveaway
+type Giveaway struct {
+	Chats                         []*Chat  `json:"chats"`
+	WinnersSelectionDate          int64    `json:"winners_selection_date"`
+	WinnerCount                   int      `json:"winner_count"`
+	OnlyNewMembers                bool     `json:"only_new_members"`
+	HasPublicWinners              bool     `json:"has_public_winners"`
+	PrizeDescription              string   `json:"prize_description"`
+	CountryCodes                  []string `json:"country_codes"`
+	PrizeStarCount                int      `json:"prize_star_count"`
+	PremiumSubscriptionMonthCount int      `json:"premium_subscription_month_count"`
+}
+
+// GiveawayCreated represents a service message about the creation of a scheduled giveaway
+type GiveawayCreated struct {
+	PrizeStarCount int `json:"prize_star_count"`
+}
+
+// GiveawayWinners represents a message about the completion of a giveaway with public winners
+type GiveawayWinners struct {
+	Chat                          Chat    `json:"chat"`
+	GiveawayMessageID             int     `json:"giveaway_message_id"`
+	WinnersSelectionDate          int64   `json:"winners_selection_date"`
+	WinnerCount                   int     `json:"winner_count"`
+	Winners                       []*User `json:"winners"`
+	AdditionalChatCount           int     `json:"additional_chat_count"`
+	PrizeStarCount                int     `json:"prize_star_count"`
+	PremiumSubscriptionMonthCount int     `json:"premium_subscription_month_count"`
+	UnclaimedPrizeCount           int     `json:"unclaimed_prize_count"`
+	OnlyNewMembers                bool    `json:"only_new_members"`
+	WasRefunded                   bool    `json:"was_refunded"`
+	PrizeDescription              string  `json:"prize_description"`
+}
+
+// GiveawayCompleted represents a service message about the completion of a giveaway without public winners
+type GiveawayCompleted struct {
+	WinnerCount         int      `json:"winner_count"`
+	UnclaimedPrizeCount int      `json:"unclaimed_prize_count"`
+	GiveawayMessage     *Message `json:"giveaway_message"`
+	IsStarGiveaway      bool     `json:"is_star_giveaway"`
+}
+
+// WebAppData describes data sent from a Web App to the bot
+type WebAppData struct {
+	Data       string `json:"data"`
+	ButtonText string `json:"button_text"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type alias Message
+	s := &struct {
+		*alias
+		ForwardOrigin json.RawMessage `json:"forward_origin"`
+	}{alias: (*alias)(m)}
+	err := json.Unmarshal(data, s)
+	if err != nil {
+		return err
+	}
+	m.ForwardOrigin, err = unmarshalMessageOrigin(s.ForwardOrigin)
+	return err
+}
+
+// TextQuote contains information about the quoted part of a message that is replied to by the given message
+type TextQuote struct {
+	Text     string           `json:"text"`
+	Entities []*MessageEntity `json:"entities"`
+	Position int              `json:"position"`
+	IsManual bool             `json:"is_manual"`
+}
+
+// ExternalReplyInfo contains information about a message that is being replied to,
+// which may come from another chat or forum topic
+type ExternalReplyInfo struct {
+	Origin             MessageOrigin       `json:"origin"`
+	Chat               *Chat               `json:"chat"`
+	MessageID          int                 `json:"message_id"`
+	LinkPreviewOptions *LinkPreviewOptions `json:"link_preview_options"`
+	Animation          *Animation          `json:"animation"`
+	Audio              *Audio              `json:"audio"`
+	Document           *Document           `json:"document"`
+	Photo              []*PhotoSize        `json:"photo"`
+	Sticker            *Sticker            `json:"sticker"`
+	Video              *Video              `json:"video"`
+	Voice              *Voice              `json:"voice"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (e *ExternalReplyInfo) UnmarshalJSON(data []byte) error {
+	type alias ExternalReplyInfo
+	s := &struct {
+		*alias
+		Origin json.RawMessage `json:"origin"`
+	}{alias: (*alias)(e)}
+	err := json.Unmarshal(data, s)
+	if err != nil {
+		return err
+	}
+	e.Origin, err = unmarshalMessageOrigin(s.Origin)
+	return err
+}
+
+// MessageOrigin describes the origin of a message, it can be one of
+// MessageOriginUser, MessageOriginHiddenUser, MessageOriginChat or MessageOriginChannel
+type MessageOrigin interface {
+	messageOrigin()
+}
+
+var (
+	_ MessageOrigin = MessageOriginUser{}
+	_ MessageOrigin = MessageOriginHiddenUser{}
+	_ MessageOrigin = MessageOriginChat{}
+	_ MessageOrigin = MessageOriginChannel{}
+)
+
+// MessageOriginUser means the message was originally sent by a known user
+type MessageOriginUser struct {
+	Type       string `json:"type"`
+	Date       int64  `json:"date"`
+	SenderUser User   `json:"sender_user"`
+}
+
+func (MessageOriginUser) messageOrigin() {}
+
+// MessageOriginHiddenUser means the message was originally sent by an unknown user
+type MessageOriginHiddenUser struct {
+	Type           string `json:"type"`
+	Date           int64  `json:"date"`
+	SenderUserName string `json:"sender_user_name"`
+}
+
+func (MessageOriginHiddenUser) messageOrigin() {}
+
+// MessageOriginChat means the message was originally sent on behalf of a chat to a group chat
+type MessageOriginChat struct {
+	Type            string `json:"type"`
+	Date            int64  `json:"date"`
+	SenderChat      Chat   `json:"sender_chat"`
+	AuthorSignature string `json:"author_signature"`
+}
+
+func (MessageOriginChat) messageOrigin() {}
+
+// MessageOriginChannel means the message was originally sent to a channel chat
+type MessageOriginChannel struct {
+	Type            string `json:"type"`
+	Date            int64  `json:"date"`
+	Chat            Chat   `json:"chat"`
+	MessageID       int    `json:"message_id"`
+	AuthorSignature string `json:"author_signature"`
+}
+
+func (MessageOriginChannel) messageOrigin() {}
+
+func unmarshalMessageOrigin(data json.RawMessage) (MessageOrigin, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	t := &struct {
+		Type string `json:"type"`
+	}{}
+	err := json.Unmarshal(data, t)
+	if err != nil {
+		return nil, err
+	}
+	switch t.Type {
+	case "user":
+		o := MessageOriginUser{}
+		err = json.Unmarshal(data, &o)
+		return o, err
+	case "hidden_user":
+		o := MessageOriginHiddenUser{}
+		err = json.Unmarshal(data, &o)
+		return o, err
+	case "chat":
+		o := MessageOriginChat{}
+		err = json.Unmarshal(data, &o)
+		return o, err
+	case "channel":
+		o := MessageOriginChannel{}
+		err = json.Unmarshal(data, &o)
+		return o, err
+	}
+	return nil, nil
+}
+
+// ProximityAlertTriggered represents the content of a service message,
+// sent whenever a user in the chat triggers a proximity alert set by another user
+type ProximityAlertTriggered struct {
+	Traveler User `json:"traveler"`
+	Watcher  User `json:"watcher"`
+	Distance int  `json:"distance"`
+}
+
+// MessageAutoDeleteTimerChanged represents a service message about a change in auto-delete timer settings
+type MessageAutoDeleteTimerChanged struct {
+	MessageAutoDeleteTime int `json:"message_auto_delete_time"`
+}
+
+// VideoChatScheduled represents a service message about a video chat scheduled in the chat
+type VideoChatScheduled struct {
+	StartDate int64 `json:"start_date"`
+}
+
+// VideoChatStarted represents a service message about a video chat started in the chat
+type VideoChatStarted struct{}
+
+// VideoChatEnded represents a service message about a video chat ended in the chat
+type VideoChatEnded struct {
+	Duration int `json:"duration"`
+}
+
+// VideoChatParticipantsInvited represents a service message about new members invited to a video chat
+type VideoChatParticipantsInvited struct {
+	Users []*User `json:"users"`
 }
 
 // InlineQuery represents an incoming inline query
@@ -348,17 +762,353 @@ type PreCheckoutQuery struct {
 // UpdateID is unique identifier
 // At most one of the other fields can be not nil
 type Update struct {
-	UpdateID           int                 `json:"update_id"`
-	Message            *Message            `json:"message"`
-	EditedMessage      *Message            `json:"edited_message"`
-	ChannelPost        *Message            `json:"channel_post"`
-	EditedChannelPost  *Message            `json:"edited_channel_post"`
-	InlineQuery        *InlineQuery        `json:"inline_query"`
-	ChosenInlineResult *ChosenInlineResult `json:"chosen_inline_result"`
-	CallbackQuery      *CallbackQuery      `json:"callback_query"`
-	ShippingQuery      *ShippingQuery      `json:"shipping_query"`
-	PreCheckoutQuery   *PreCheckoutQuery   `json:"pre_checkout_query"`
-	Poll               *Poll               `json:"poll"`
+	UpdateID             int                          `json:"update_id"`
+	Message              *Message                     `json:"message"`
+	EditedMessage        *Message                     `json:"edited_message"`
+	ChannelPost          *Message                     `json:"channel_post"`
+	EditedChannelPost    *Message                     `json:"edited_channel_post"`
+	InlineQuery          *InlineQuery                 `json:"inline_query"`
+	ChosenInlineResult   *ChosenInlineResult          `json:"chosen_inline_result"`
+	CallbackQuery        *CallbackQuery               `json:"callback_query"`
+	ShippingQuery        *ShippingQuery               `json:"shipping_query"`
+	PreCheckoutQuery     *PreCheckoutQuery            `json:"pre_checkout_query"`
+	Poll                 *Poll                        `json:"poll"`
+	PollAnswer           *PollAnswer                  `json:"poll_answer"`
+	MyChatMember         *ChatMemberUpdated           `json:"my_chat_member"`
+	ChatMember           *ChatMemberUpdated           `json:"chat_member"`
+	ChatJoinRequest      *ChatJoinRequest             `json:"chat_join_request"`
+	MessageReaction      *MessageReactionUpdated      `json:"message_reaction"`
+	MessageReactionCount *MessageReactionCountUpdated `json:"message_reaction_count"`
+	BusinessConnection   *BusinessConnection          `json:"business_connection"`
+	ChatBoost            *ChatBoostUpdated            `json:"chat_boost"`
+	RemovedChatBoost     *ChatBoostRemoved            `json:"removed_chat_boost"`
+}
+
+// Update types for OptAllowedUpdates and OptWebhookAllowedUpdates
+const (
+	UpdateTypeMessage              = "message"
+	UpdateTypeEditedMessage        = "edited_message"
+	UpdateTypeChannelPost          = "channel_post"
+	UpdateTypeEditedChannelPost    = "edited_channel_post"
+	UpdateTypeInlineQuery          = "inline_query"
+	UpdateTypeChosenInlineResult   = "chosen_inline_result"
+	UpdateTypeCallbackQuery        = "callback_query"
+	UpdateTypeShippingQuery        = "shipping_query"
+	UpdateTypePreCheckoutQuery     = "pre_checkout_query"
+	UpdateTypePoll                 = "poll"
+	UpdateTypePollAnswer           = "poll_answer"
+	UpdateTypeMyChatMember         = "my_chat_member"
+	UpdateTypeChatMember           = "chat_member"
+	UpdateTypeChatJoinRequest      = "chat_join_request"
+	UpdateTypeMessageReaction      = "message_reaction"
+	UpdateTypeMessageReactionCount = "message_reaction_count"
+	UpdateTypeBusinessConnection   = "business_connection"
+	UpdateTypeChatBoost            = "chat_boost"
+	UpdateTypeRemovedChatBoost     = "removed_chat_boost"
+)
+
+// message returns message of any message update type
+func (u *Update) message() *Message {
+	switch {
+	case u == nil:
+		return nil
+	case u.Message != nil:
+		return u.Message
+	case u.EditedMessage != nil:
+		return u.EditedMessage
+	case u.ChannelPost != nil:
+		return u.ChannelPost
+	}
+	return u.EditedChannelPost
+}
+
+// From returns sender of the update or nil if update has no sender
+func (u *Update) From() *User {
+	if m := u.message(); m != nil {
+		return m.From
+	}
+	switch {
+	case u == nil:
+		return nil
+	case u.InlineQuery != nil:
+		return u.InlineQuery.From
+	case u.ChosenInlineResult != nil:
+		return u.ChosenInlineResult.From
+	case u.CallbackQuery != nil:
+		return u.CallbackQuery.From
+	case u.ShippingQuery != nil:
+		return u.ShippingQuery.From
+	case u.PreCheckoutQuery != nil:
+		return u.PreCheckoutQuery.From
+	case u.PollAnswer != nil:
+		return u.PollAnswer.User
+	case u.MyChatMember != nil:
+		return &u.MyChatMember.From
+	case u.ChatMember != nil:
+		return &u.ChatMember.From
+	case u.ChatJoinRequest != nil:
+		return &u.ChatJoinRequest.From
+	case u.MessageReaction != nil:
+		return u.MessageReaction.User
+	case u.BusinessConnection != nil:
+		return &u.BusinessConnection.User
+	}
+	return nil
+}
+
+// ChatID returns ID of the chat the update belongs to or empty string
+func (u *Update) ChatID() string {
+	if m := u.message(); m != nil {
+		return m.Chat.ID
+	}
+	switch {
+	case u == nil:
+		return ""
+	case u.CallbackQuery != nil && u.CallbackQuery.Message != nil:
+		return u.CallbackQuery.Message.Chat.ID
+	case u.MyChatMember != nil:
+		return u.MyChatMember.Chat.ID
+	case u.ChatMember != nil:
+		return u.ChatMember.Chat.ID
+	case u.ChatJoinRequest != nil:
+		return u.ChatJoinRequest.Chat.ID
+	case u.MessageReaction != nil:
+		return u.MessageReaction.Chat.ID
+	case u.MessageReactionCount != nil:
+		return u.MessageReactionCount.Chat.ID
+	case u.ChatBoost != nil:
+		return u.ChatBoost.Chat.ID
+	case u.RemovedChatBoost != nil:
+		return u.RemovedChatBoost.Chat.ID
+	}
+	return ""
+}
+
+// Text returns message text or callback query data
+func (u *Update) Text() string {
+	if m := u.message(); m != nil {
+		return m.Text
+	}
+	if u != nil && u.CallbackQuery != nil {
+		return u.CallbackQuery.Data
+	}
+	return ""
+}
+
+// IsMessage reports whether update is a new message
+func (u *Update) IsMessage() bool {
+	return u != nil && u.Message != nil
+}
+
+// IsCallbackQuery reports whether update is a callback query
+func (u *Update) IsCallbackQuery() bool {
+	return u != nil && u.CallbackQuery != nil
+}
+
+// IsInlineQuery reports whether update is an inline query
+func (u *Update) IsInlineQuery() bool {
+	return u != nil && u.InlineQuery != nil
+}
+
+// IsEdited reports whether update is an edited message or channel post
+func (u *Update) IsEdited() bool {
+	return u != nil && (u.EditedMessage != nil || u.EditedChannelPost != nil)
+}
+
+// IsChannelPost reports whether update is a new or edited channel post
+func (u *Update) IsChannelPost() bool {
+	return u != nil && (u.ChannelPost != nil || u.EditedChannelPost != nil)
+}
+
+// PollAnswer represents an answer of a user in a non-anonymous poll
+type PollAnswer struct {
+	PollID    string `json:"poll_id"`
+	VoterChat *Chat  `json:"voter_chat"`
+	User      *User  `json:"user"`
+	OptionIDs []int  `json:"option_ids"`
+}
+
+// ChatMemberUpdated represents changes in the status of a chat member
+type ChatMemberUpdated struct {
+	Chat          Chat            `json:"chat"`
+	From          User            `json:"from"`
+	Date          int             `json:"date"`
+	OldChatMember ChatMember      `json:"old_chat_member"`
+	NewChatMember ChatMember      `json:"new_chat_member"`
+	InviteLink    *ChatInviteLink `json:"invite_link"`
+}
+
+// ChatJoinRequest represents a join request sent to a chat
+type ChatJoinRequest struct {
+	Chat       Chat   `json:"chat"`
+	From       User   `json:"from"`
+	UserChatID string `json:"user_chat_id"`
+	Date       int    `json:"date"`
+	Bio        string `json:"bio"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cjr *ChatJoinRequest) UnmarshalJSON(data []byte) error {
+	type alias ChatJoinRequest
+	s := &struct {
+		*alias
+		UserChatID int `json:"user_chat_id"`
+	}{alias: (*alias)(cjr)}
+	err := json.Unmarshal(data, s)
+	if err != nil {
+		return err
+	}
+	cjr.UserChatID = fmt.Sprint(s.UserChatID)
+	return nil
+}
+
+// ChatBoostUpdated represents a boost added to a chat or changed
+type ChatBoostUpdated struct {
+	Chat  Chat      `json:"chat"`
+	Boost ChatBoost `json:"boost"`
+}
+
+// ChatBoostRemoved represents a boost removed from a chat
+type ChatBoostRemoved struct {
+	Chat       Chat            `json:"chat"`
+	BoostID    string          `json:"boost_id"`
+	RemoveDate int             `json:"remove_date"`
+	Source     ChatBoostSource `json:"source"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (cb *ChatBoostRemoved) UnmarshalJSON(data []byte) error {
+	type alias ChatBoostRemoved
+	s := &struct {
+		*alias
+		Source json.RawMessage `json:"source"`
+	}{alias: (*alias)(cb)}
+	err := json.Unmarshal(data, s)
+	if err != nil {
+		return err
+	}
+	cb.Source, err = unmarshalChatBoostSource(s.Source)
+	return err
+}
+
+// BusinessConnection describes the connection of the bot with a business account
+type BusinessConnection struct {
+	ID         string `json:"id"`
+	User       User   `json:"user"`
+	UserChatID string `json:"user_chat_id"`
+	Date       int    `json:"date"`
+	CanReply   bool   `json:"can_reply"`
+	IsEnabled  bool   `json:"is_enabled"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (bc *BusinessConnection) UnmarshalJSON(data []byte) error {
+	type alias BusinessConnection
+	s := &struct {
+		*alias
+		UserChatID int `json:"user_chat_id"`
+	}{alias: (*alias)(bc)}
+	err := json.Unmarshal(data, s)
+	if err != nil {
+		return err
+	}
+	bc.UserChatID = fmt.Sprint(s.UserChatID)
+	return nil
+}
+
+// MessageReactionUpdated represents a change of a reaction on a message performed by a user
+type MessageReactionUpdated struct {
+	Chat        Chat           `json:"chat"`
+	MessageID   int            `json:"message_id"`
+	User        *User          `json:"user"`
+	ActorChat   *Chat          `json:"actor_chat"`
+	Date        int            `json:"date"`
+	OldReaction []ReactionType `json:"old_reaction"`
+	NewReaction []ReactionType `json:"new_reaction"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (m *MessageReactionUpdated) UnmarshalJSON(data []byte) error {
+	type alias MessageReactionUpdated
+	s := &struct {
+		*alias
+		OldReaction []json.RawMessage `json:"old_reaction"`
+		NewReaction []json.RawMessage `json:"new_reaction"`
+	}{alias: (*alias)(m)}
+	err := json.Unmarshal(data, s)
+	if err != nil {
+		return err
+	}
+	m.OldReaction, err = unmarshalReactionTypes(s.OldReaction)
+	if err != nil {
+		return err
+	}
+	m.NewReaction, err = unmarshalReactionTypes(s.NewReaction)
+	return err
+}
+
+// ReactionCount represents a reaction added to a message along with the number of times it was added
+type ReactionCount struct {
+	Type       ReactionType `json:"type"`
+	TotalCount int          `json:"total_count"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (rc *ReactionCount) UnmarshalJSON(data []byte) error {
+	s := &struct {
+		Type       json.RawMessage `json:"type"`
+		TotalCount int             `json:"total_count"`
+	}{}
+	err := json.Unmarshal(data, s)
+	if err != nil {
+		return err
+	}
+	rc.TotalCount = s.TotalCount
+	rc.Type, err = unmarshalReactionType(s.Type)
+	return err
+}
+
+// MessageReactionCountUpdated represents reaction changes on a message with anonymous reactions
+type MessageReactionCountUpdated struct {
+	Chat      Chat            `json:"chat"`
+	MessageID int             `json:"message_id"`
+	Date      int             `json:"date"`
+	Reactions []ReactionCount `json:"reactions"`
+}
+
+func unmarshalReactionType(data json.RawMessage) (ReactionType, error) {
+	t := &struct {
+		Type string `json:"type"`
+	}{}
+	err := json.Unmarshal(data, t)
+	if err != nil {
+		return nil, err
+	}
+	switch t.Type {
+	case "emoji":
+		r := ReactionTypeEmoji{}
+		err = json.Unmarshal(data, &r)
+		return r, err
+	case "custom_emoji":
+		r := ReactionTypeCustomEmoji{}
+		err = json.Unmarshal(data, &r)
+		return r, err
+	}
+	return nil, nil
+}
+
+func unmarshalReactionTypes(data []json.RawMessage) ([]ReactionType, error) {
+	reactions := make([]ReactionType, 0, len(data))
+	for _, d := range data {
+		r, err := unmarshalReactionType(d)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			reactions = append(reactions, r)
+		}
+	}
+	return reactions, nil
 }
 
 // PassportData contains information about Telegram Passport data shared with the bot by the user
@@ -0,0 +1,129 @@
+package tbot_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestUnmarshalMessageReaction(t *testing.T) {
+	data := `{
+		"update_id": 1,
+		"message_reaction": {
+			"chat": {"id": 1},
+			"message_id": 2,
+			"old_reaction": [],
+			"new_reaction": [{"type": "emoji", "emoji": "👍"}, {"type": "custom_emoji", "custom_emoji_id": "42"}]
+		}
+	}`
+	up := &tbot.Update{}
+	err := json.Unmarshal([]byte(data), up)
+	if err != nil {
+		t.Fatalf("unable to unmarshal update: %v", err)
+	}
+	r := up.MessageReaction
+	if r == nil || len(r.NewReaction) != 2 {
+		t.Fatalf("unexpected reaction update: %+v", r)
+	}
+	if e, ok := r.NewReaction[0].(tbot.ReactionTypeEmoji); !ok || e.Emoji != "👍" {
+		t.Fatalf("unexpected first reaction: %+v", r.NewReaction[0])
+	}
+	if e, ok := r.NewReaction[1].(tbot.ReactionTypeCustomEmoji); !ok || e.CustomEmojiID != "42" {
+		t.Fatalf("unexpected second reaction: %+v", r.NewReaction[1])
+	}
+}
+
+func TestUnmarshalBusinessConnection(t *testing.T) {
+	data := `{"id": "conn", "user": {"id": 1}, "user_chat_id": 123, "can_reply": true}`
+	bc := &tbot.BusinessConnection{}
+	err := json.Unmarshal([]byte(data), bc)
+	if err != nil {
+		t.Fatalf("unable to unmarshal business connection: %v", err)
+	}
+	if bc.ID != "conn" || bc.UserChatID != "123" || !bc.CanReply {
+		t.Fatalf("unexpected business connection: %+v", bc)
+	}
+}
+
+func TestUpdateHelpers(t *testing.T) {
+	var nilUpdate *tbot.Update
+	if nilUpdate.From() != nil || nilUpdate.ChatID() != "" || nilUpdate.Text() != "" || nilUpdate.IsMessage() {
+		t.Fatalf("nil update helpers must return zero values")
+	}
+	up := &tbot.Update{
+		CallbackQuery: &tbot.CallbackQuery{
+			From:    &tbot.User{ID: 1},
+			Message: &tbot.Message{Chat: tbot.Chat{ID: "42"}},
+			Data:    "data",
+		},
+	}
+	if !up.IsCallbackQuery() || up.IsMessage() {
+		t.Fatalf("unexpected update type")
+	}
+	if up.From().ID != 1 || up.ChatID() != "42" || up.Text() != "data" {
+		t.Fatalf("unexpected helper values: %v %q %q", up.From(), up.ChatID(), up.Text())
+	}
+	up = &tbot.Update{EditedChannelPost: &tbot.Message{Text: "edited"}}
+	if !up.IsEdited() || !up.IsChannelPost() || up.Text() != "edited" {
+		t.Fatalf("unexpected edited channel post helpers")
+	}
+}
+
+func TestUnmarshalForwardOrigin(t *testing.T) {
+	data := `{
+		"message_id": 5,
+		"chat": {"id": 1, "type": "private"},
+		"text": "hi",
+		"forward_origin": {"type": "channel", "date": 1700000000, "chat": {"id": -100, "type": "channel"}, "message_id": 7},
+		"external_reply": {"origin": {"type": "hidden_user", "date": 1700000000, "sender_user_name": "Anon"}, "message_id": 3}
+	}`
+	msg := &tbot.Message{}
+	err := json.Unmarshal([]byte(data), msg)
+	if err != nil {
+		t.Fatalf("unable to unmarshal message: %v", err)
+	}
+	if msg.Text != "hi" || msg.Chat.ID != "1" {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+	origin, ok := msg.ForwardOrigin.(tbot.MessageOriginChannel)
+	if !ok || origin.MessageID != 7 || origin.Chat.ID != "-100" {
+		t.Fatalf("unexpected forward origin: %+v", msg.ForwardOrigin)
+	}
+	if msg.ExternalReply == nil {
+		t.Fatalf("external reply is not decoded")
+	}
+	if hidden, ok := msg.ExternalReply.Origin.(tbot.MessageOriginHiddenUser); !ok || hidden.SenderUserName != "Anon" {
+		t.Fatalf("unexpected external reply origin: %+v", msg.ExternalReply.Origin)
+	}
+}
+
+func TestUnmarshalChatBackground(t *testing.T) {
+	data := `{"message_id": 1, "chat": {"id": 1}, "chat_background_set": {"type": {"type": "chat_theme", "theme_name": "🌷"}}}`
+	msg := &tbot.Message{}
+	err := json.Unmarshal([]byte(data), msg)
+	if err != nil {
+		t.Fatalf("unable to unmarshal message: %v", err)
+	}
+	if msg.ChatBackgroundSet == nil {
+		t.Fatalf("chat background is not decoded")
+	}
+	if theme, ok := msg.ChatBackgroundSet.Type.(tbot.BackgroundTypeChatTheme); !ok || theme.ThemeName != "🌷" {
+		t.Fatalf("unexpected background type: %+v", msg.ChatBackgroundSet.Type)
+	}
+}
+
+func TestUnmarshalFullChat(t *testing.T) {
+	data := `{"id": -100, "type": "supergroup", "photo": {"small_file_id": "s", "small_file_unique_id": "su", "big_file_id": "b", "big_file_unique_id": "bu"}, "permissions": {"can_send_messages": true}, "slow_mode_delay": 30}`
+	chat := &tbot.Chat{}
+	err := json.Unmarshal([]byte(data), chat)
+	if err != nil {
+		t.Fatalf("unable to unmarshal chat: %v", err)
+	}
+	if chat.Photo == nil || chat.Photo.BigFileUniqueID != "bu" {
+		t.Fatalf("unexpected chat photo: %+v", chat.Photo)
+	}
+	if chat.Permissions == nil || !chat.Permissions.CanSendMessages || chat.SlowModeDelay != 30 {
+		t.Fatalf("unexpected chat: %+v", chat)
+	}
+}
@@ -0,0 +1,174 @@
+package tbot
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// ErrInvalidSecret is returned when webhook request secret token doesn't match
+var ErrInvalidSecret = errors.New("invalid webhook secret token")
+
+// ValidateWebhookRequest checks secret token of the webhook request and decodes Update from its body.
+// Empty secretToken disables the check.
+func ValidateWebhookRequest(r *http.Request, secretToken string) (*Update, error) {
+	if !validSecret(r, secretToken) {
+		return nil, ErrInvalidSecret
+	}
+	return DecodeUpdate(r.Body)
+}
+
+// DecodeUpdate decodes Update from JSON body, e.g. received by a serverless function
+func DecodeUpdate(body io.Reader) (*Update, error) {
+	up := &Update{}
+	err := json.NewDecoder(body).Decode(up)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode update: %v", err)
+	}
+	return up, nil
+}
+
+// validSecret checks webhook request secret token header, empty secret accepts any request
+func validSecret(r *http.Request, secret string) bool {
+	if secret == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get(secretTokenHeader)), []byte(secret)) == 1
+}
+
+// WebhookServer receives updates sent by Telegram to the webhook
+// and delivers them to the Updates channel
+type WebhookServer struct {
+	client  *Client
+	updates chan *Update
+
+	mu       sync.RWMutex
+	closed   bool
+	inFlight sync.WaitGroup
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// NewWebhookServer creates new WebhookServer for the client
+func NewWebhookServer(client *Client) *WebhookServer {
+	return &WebhookServer{
+		client:  client,
+		updates: make(chan *Update, client.bufferSize),
+		done:    make(chan struct{}),
+	}
+}
+
+// Handler returns http.Handler serving webhook updates on the given path
+func (ws *WebhookServer) Handler(path string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, ws.serveUpdate)
+	return mux
+}
+
+// Updates returns channel of received updates. It is closed after Shutdown.
+func (ws *WebhookServer) Updates() <-chan *Update {
+	return ws.updates
+}
+
+// Shutdown stops accepting new updates and waits until in-flight updates
+// are delivered to the Updates channel, then closes it.
+// If ctx is done first, undelivered updates are rejected and ctx error is returned.
+func (ws *WebhookServer) Shutdown(ctx context.Context) error {
+	ws.mu.Lock()
+	if ws.closed {
+		ws.mu.Unlock()
+		return nil
+	}
+	ws.closed = true
+	ws.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		ws.inFlight.Wait()
+		close(drained)
+	}()
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+		ws.doneOnce.Do(func() { close(ws.done) })
+		<-drained
+	}
+	ws.doneOnce.Do(func() { close(ws.done) })
+	close(ws.updates)
+	return err
+}
+
+func (ws *WebhookServer) serveUpdate(w http.ResponseWriter, r *http.Request) {
+	ws.mu.RLock()
+	if ws.closed {
+		ws.mu.RUnlock()
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		return
+	}
+	ws.inFlight.Add(1)
+	ws.mu.RUnlock()
+	defer ws.inFlight.Done()
+
+	up, ok := readWebhookUpdate(w, r, ws.client.webhookSecret, ws.client.logger)
+	if !ok {
+		return
+	}
+	select {
+	case ws.updates <- up:
+	case <-ws.done:
+		http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+	}
+}
+
+// readWebhookUpdate validates request and writes error response on failure
+func readWebhookUpdate(w http.ResponseWriter, r *http.Request, secret string, logger Logger) (*Update, bool) {
+	up, err := ValidateWebhookRequest(r, secret)
+	if err == ErrInvalidSecret {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return nil, false
+	}
+	if err != nil {
+		logger.Error(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return up, true
+}
+
+// WebhookMux serves webhooks of several bots on a single HTTP server.
+// Every path is validated with the secret token of its own client.
+type WebhookMux struct {
+	mux *http.ServeMux
+}
+
+// NewWebhookMux creates empty WebhookMux
+func NewWebhookMux() *WebhookMux {
+	return &WebhookMux{mux: http.NewServeMux()}
+}
+
+// Handle registers handler for updates of the client received on path.
+// Telegram gets the response after handler returns.
+func (m *WebhookMux) Handle(path string, client *Client, handler func(*Update)) *WebhookMux {
+	m.mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		up, ok := readWebhookUpdate(w, r, client.webhookSecret, client.logger)
+		if !ok {
+			return
+		}
+		handler(up)
+	})
+	return m
+}
+
+// ServeHTTP implements http.Handler
+func (m *WebhookMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mux.ServeHTTP(w, r)
+}
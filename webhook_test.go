@@ -0,0 +1,123 @@
+package tbot_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestWebhookServer(t *testing.T) {
+	ws := tbot.NewWebhookServer(tbot.NewClient(token, nil, "https://example.com"))
+	h := ws.Handler("/hook")
+	received := make(chan *tbot.Update, 1)
+	go func() {
+		for up := range ws.Updates() {
+			received <- up
+		}
+		close(received)
+	}()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(`{"update_id": 5}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", rec.Code)
+	}
+	if up := <-received; up.UpdateID != 5 {
+		t.Fatalf("unexpected update: %+v", up)
+	}
+
+	err := ws.Shutdown(context.Background())
+	if err != nil {
+		t.Fatalf("error on shutdown: %v", err)
+	}
+	if _, ok := <-received; ok {
+		t.Fatalf("updates channel is not closed")
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(`{"update_id": 6}`)))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected rejection after shutdown, got %d", rec.Code)
+	}
+}
+
+func TestWebhookServerSecret(t *testing.T) {
+	c := tbot.NewClient(token, nil, "https://example.com", tbot.WithWebhookSecret("secret"))
+	ws := tbot.NewWebhookServer(c)
+	h := ws.Handler("/hook")
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(`{"update_id": 1}`))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "wrong")
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected forbidden, got %d", rec.Code)
+	}
+}
+
+func TestValidateWebhookRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"update_id": 3}`))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "secret")
+	up, err := tbot.ValidateWebhookRequest(req, "secret")
+	if err != nil {
+		t.Fatalf("error on validate: %v", err)
+	}
+	if up.UpdateID != 3 {
+		t.Fatalf("unexpected update: %+v", up)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"update_id": 3}`))
+	_, err = tbot.ValidateWebhookRequest(req, "secret")
+	if err != tbot.ErrInvalidSecret {
+		t.Fatalf("expected ErrInvalidSecret, got %v", err)
+	}
+}
+
+func TestWebhookMux(t *testing.T) {
+	first := tbot.NewClient("first", nil, "https://example.com", tbot.WithWebhookSecret("one"))
+	second := tbot.NewClient("second", nil, "https://example.com", tbot.WithWebhookSecret("two"))
+	received := make(chan string, 1)
+	mux := tbot.NewWebhookMux().
+		Handle("/first", first, func(*tbot.Update) { received <- "first" }).
+		Handle("/second", second, func(*tbot.Update) { received <- "second" })
+
+	req := httptest.NewRequest(http.MethodPost, "/second", strings.NewReader(`{"update_id": 1}`))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "one")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected forbidden for secret of another bot, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/second", strings.NewReader(`{"update_id": 1}`))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", "two")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", rec.Code)
+	}
+	select {
+	case bot := <-received:
+		if bot != "second" {
+			t.Fatalf("update is handled by %s bot", bot)
+		}
+	default:
+		t.Fatalf("update is not handled before response")
+	}
+}
+
+func TestDecodeUpdate(t *testing.T) {
+	up, err := tbot.DecodeUpdate(strings.NewReader(`{"update_id": 3, "message": {"message_id": 1, "text": "hi"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if up.UpdateID != 3 || up.Text() != "hi" {
+		t.Fatalf("unexpected update: %+v", up)
+	}
+	if _, err := tbot.DecodeUpdate(strings.NewReader("not json")); err == nil {
+		t.Fatalf("expected decode error")
+	}
+}
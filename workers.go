@@ -0,0 +1,75 @@
+package tbot
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// WorkerPool handles updates concurrently with a fixed number of goroutines
+type WorkerPool struct {
+	n       int
+	updates <-chan *Update
+	handler func(*Update)
+	logger  Logger
+}
+
+// NewWorkerPool creates new WorkerPool with n workers
+func NewWorkerPool(n int) *WorkerPool {
+	if n < 1 {
+		n = 1
+	}
+	return &WorkerPool{n: n, logger: nopLogger{}}
+}
+
+// WithLogger sets logger for panics recovered in handler, they are not logged by default
+func (wp *WorkerPool) WithLogger(logger Logger) *WorkerPool {
+	wp.logger = logger
+	return wp
+}
+
+// Process sets updates source and handler for the pool
+func (wp *WorkerPool) Process(updates <-chan *Update, handler func(*Update)) *WorkerPool {
+	wp.updates = updates
+	wp.handler = handler
+	return wp
+}
+
+// Start runs workers until ctx is done or updates channel is closed.
+// It returns after all in-flight handlers have returned.
+// Panics in handler are recovered and logged.
+func (wp *WorkerPool) Start(ctx context.Context) error {
+	if wp.updates == nil || wp.handler == nil {
+		return fmt.Errorf("updates and handler must be set with Process")
+	}
+	var wg sync.WaitGroup
+	wg.Add(wp.n)
+	for i := 0; i < wp.n; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case up, ok := <-wp.updates:
+					if !ok {
+						return
+					}
+					wp.handle(up)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+func (wp *WorkerPool) handle(up *Update) {
+	defer func() {
+		if r := recover(); r != nil {
+			wp.logger.Errorf("panic in update handler: %v\n%s", r, debug.Stack())
+		}
+	}()
+	wp.handler(up)
+}
@@ -0,0 +1,37 @@
+package tbot_test
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yanzay/tbot/v2"
+)
+
+func TestWorkerPool(t *testing.T) {
+	updates := make(chan *tbot.Update, 3)
+	for i := 1; i <= 3; i++ {
+		updates <- &tbot.Update{UpdateID: i}
+	}
+	close(updates)
+	var handled int32
+	buf := &bytes.Buffer{}
+	err := tbot.NewWorkerPool(2).WithLogger(tbot.NewStdLogger(log.New(buf, "", 0))).Process(updates, func(up *tbot.Update) {
+		atomic.AddInt32(&handled, 1)
+		if up.UpdateID == 2 {
+			panic("handler failure")
+		}
+	}).Start(context.Background())
+	if err != nil {
+		t.Fatalf("error on start: %v", err)
+	}
+	if handled != 3 {
+		t.Fatalf("expected 3 handled updates, got %d", handled)
+	}
+	if !strings.Contains(buf.String(), "panic in update handler: handler failure") {
+		t.Errorf("panic is not logged: %q", buf.String())
+	}
+}